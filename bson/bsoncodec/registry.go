@@ -65,6 +65,8 @@ type RegistryBuilder struct {
 	interfaceDecoders []interfaceValueDecoder
 	kindDecoders      map[reflect.Kind]ValueDecoder
 
+	typeDecoderPriorities map[reflect.Type]int
+
 	typeMap map[bsontype.Type]reflect.Type
 }
 
@@ -91,6 +93,8 @@ func NewRegistryBuilder() *RegistryBuilder {
 		typeEncoders: make(map[reflect.Type]ValueEncoder),
 		typeDecoders: make(map[reflect.Type]ValueDecoder),
 
+		typeDecoderPriorities: make(map[reflect.Type]int),
+
 		interfaceEncoders: make([]interfaceValueEncoder, 0),
 		interfaceDecoders: make([]interfaceValueDecoder, 0),
 
@@ -155,14 +159,19 @@ func (rb *RegistryBuilder) RegisterHookEncoder(t reflect.Type, enc ValueEncoder)
 //
 // If the given type is an interface, the decoder will be called when unmarshalling into a type that is that interface.
 // It will not be called when unmarshalling into a non-interface type that implements the interface.
+//
+// The decoder replaces any decoder previously registered for the type, including one registered with
+// RegisterDecoderWithPriority.
 func (rb *RegistryBuilder) RegisterTypeDecoder(t reflect.Type, dec ValueDecoder) *RegistryBuilder {
-	rb.typeDecoders[t] = dec
+	rb.setTypeDecoder(t, dec)
 	return rb
 }
 
 // RegisterHookDecoder will register an decoder for the provided interface type t. This decoder will be called when
 // unmarshalling into a type if the type implements t or a pointer to the type implements t. If the provided type is not
 // an interface (i.e. t.Kind() != reflect.Interface), this method will panic.
+//
+// This is equivalent to calling RegisterDecoderWithPriority with a priority of 0 for an interface type.
 func (rb *RegistryBuilder) RegisterHookDecoder(t reflect.Type, dec ValueDecoder) *RegistryBuilder {
 	if t.Kind() != reflect.Interface {
 		panicStr := fmt.Sprintf("RegisterHookDecoder expects a type with kind reflect.Interface, "+
@@ -170,15 +179,70 @@ func (rb *RegistryBuilder) RegisterHookDecoder(t reflect.Type, dec ValueDecoder)
 		panic(panicStr)
 	}
 
+	rb.registerHookDecoder(t, dec, 0)
+	return rb
+}
+
+// RegisterDecoderWithPriority will register the provided ValueDecoder for the provided type with the given priority.
+//
+// If t is an interface, the decoder is registered as a hook decoder as with RegisterHookDecoder. When a type
+// implements more than one interface with a registered hook decoder, the decoder with the highest priority is used.
+// Hook decoders with equal priorities are consulted in the order they were registered.
+//
+// Otherwise, the decoder is registered for the exact type as with RegisterTypeDecoder. If a decoder with a higher
+// priority has already been registered for t with RegisterDecoderWithPriority, the provided decoder is ignored. A
+// decoder registered with RegisterTypeDecoder or RegisterDecoder has no priority and is always replaced, and it
+// always replaces a decoder registered with a priority.
+func (rb *RegistryBuilder) RegisterDecoderWithPriority(t reflect.Type, dec ValueDecoder, priority int) *RegistryBuilder {
+	if t != nil && t.Kind() == reflect.Interface {
+		rb.registerHookDecoder(t, dec, priority)
+		return rb
+	}
+
+	rb.registerTypeDecoder(t, dec, priority)
+	return rb
+}
+
+func (rb *RegistryBuilder) registerTypeDecoder(t reflect.Type, dec ValueDecoder, priority int) {
+	if existing, ok := rb.typeDecoderPriorities[t]; ok && existing > priority {
+		return
+	}
+
+	rb.typeDecoders[t] = dec
+	rb.typeDecoderPriorities[t] = priority
+}
+
+// setTypeDecoder registers dec for t without a priority, replacing any decoder previously registered for t.
+func (rb *RegistryBuilder) setTypeDecoder(t reflect.Type, dec ValueDecoder) {
+	rb.typeDecoders[t] = dec
+	delete(rb.typeDecoderPriorities, t)
+}
+
+func (rb *RegistryBuilder) registerHookDecoder(t reflect.Type, dec ValueDecoder, priority int) {
 	for idx, decoder := range rb.interfaceDecoders {
-		if decoder.i == t {
+		if decoder.i != t {
+			continue
+		}
+		if decoder.priority == priority {
 			rb.interfaceDecoders[idx].vd = dec
-			return rb
+			return
 		}
+		rb.interfaceDecoders = append(rb.interfaceDecoders[:idx], rb.interfaceDecoders[idx+1:]...)
+		break
 	}
 
-	rb.interfaceDecoders = append(rb.interfaceDecoders, interfaceValueDecoder{i: t, vd: dec})
-	return rb
+	// Insert the decoder after all decoders with a greater or equal priority so the slice stays ordered by priority,
+	// highest first.
+	idx := len(rb.interfaceDecoders)
+	for i, decoder := range rb.interfaceDecoders {
+		if decoder.priority < priority {
+			idx = i
+			break
+		}
+	}
+	rb.interfaceDecoders = append(rb.interfaceDecoders, interfaceValueDecoder{})
+	copy(rb.interfaceDecoders[idx+1:], rb.interfaceDecoders[idx:])
+	rb.interfaceDecoders[idx] = interfaceValueDecoder{i: t, vd: dec, priority: priority}
 }
 
 // RegisterEncoder registers the provided type and encoder pair.
@@ -210,25 +274,18 @@ func (rb *RegistryBuilder) RegisterEncoder(t reflect.Type, enc ValueEncoder) *Re
 // Deprecated: Use RegisterTypeDecoder or RegisterHookDecoder instead.
 func (rb *RegistryBuilder) RegisterDecoder(t reflect.Type, dec ValueDecoder) *RegistryBuilder {
 	if t == nil {
-		rb.typeDecoders[nil] = dec
+		rb.setTypeDecoder(nil, dec)
 		return rb
 	}
 	if t == tEmpty {
-		rb.typeDecoders[t] = dec
+		rb.setTypeDecoder(t, dec)
 		return rb
 	}
 	switch t.Kind() {
 	case reflect.Interface:
-		for idx, ir := range rb.interfaceDecoders {
-			if ir.i == t {
-				rb.interfaceDecoders[idx].vd = dec
-				return rb
-			}
-		}
-
-		rb.interfaceDecoders = append(rb.interfaceDecoders, interfaceValueDecoder{i: t, vd: dec})
+		rb.registerHookDecoder(t, dec, 0)
 	default:
-		rb.typeDecoders[t] = dec
+		rb.setTypeDecoder(t, dec)
 	}
 	return rb
 }
@@ -464,6 +521,7 @@ type interfaceValueEncoder struct {
 }
 
 type interfaceValueDecoder struct {
-	i  reflect.Type
-	vd ValueDecoder
+	i        reflect.Type
+	vd       ValueDecoder
+	priority int
 }
@@ -43,6 +43,62 @@ func TestRegistry(t *testing.T) {
 				t.Errorf("The registered interfaces are not correct. got %v; want %v", got, want)
 			}
 		})
+		t.Run("decoder priority", func(t *testing.T) {
+			pc1, pc2, pc3, pc4 := &fakeCodec{num: 1}, &fakeCodec{num: 2}, &fakeCodec{num: 3}, &fakeCodec{num: 4}
+			t.Run("hook", func(t *testing.T) {
+				var t1f *testInterface1
+				var t2f *testInterface2
+				var t4f *testInterface4
+				ti1, ti2, ti4 := reflect.TypeOf(t1f).Elem(), reflect.TypeOf(t2f).Elem(), reflect.TypeOf(t4f).Elem()
+				rb := NewRegistryBuilder().
+					RegisterHookDecoder(ti1, pc1).
+					RegisterDecoderWithPriority(ti2, pc2, 10).
+					RegisterDecoderWithPriority(ti4, pc4, -1).
+					RegisterDecoderWithPriority(ti1, pc3, 5)
+				want := []interfaceValueDecoder{
+					{i: ti2, vd: pc2, priority: 10},
+					{i: ti1, vd: pc3, priority: 5},
+					{i: ti4, vd: pc4, priority: -1},
+				}
+				got := rb.interfaceDecoders
+				if !cmp.Equal(got, want, cmp.AllowUnexported(interfaceValueDecoder{}, fakeCodec{}), cmp.Comparer(typeComparer)) {
+					t.Errorf("The registered interfaces are not correct. got %v; want %v", got, want)
+				}
+			})
+			t.Run("type", func(t *testing.T) {
+				ft1 := reflect.TypeOf(fakeType1{})
+				rb := NewRegistryBuilder().
+					RegisterDecoderWithPriority(ft1, pc1, 2).
+					RegisterDecoderWithPriority(ft1, pc2, 1)
+				got := rb.typeDecoders[ft1]
+				if !cmp.Equal(got, ValueDecoder(pc1), cmp.AllowUnexported(fakeCodec{})) {
+					t.Errorf("Codecs did not match. got %#v; want %#v", got, pc1)
+				}
+
+				rb.RegisterDecoderWithPriority(ft1, pc3, 2)
+				got = rb.typeDecoders[ft1]
+				if !cmp.Equal(got, ValueDecoder(pc3), cmp.AllowUnexported(fakeCodec{})) {
+					t.Errorf("Codecs did not match. got %#v; want %#v", got, pc3)
+				}
+			})
+			t.Run("priority registration, then plain registration", func(t *testing.T) {
+				ft1 := reflect.TypeOf(fakeType1{})
+				rb := NewRegistryBuilder().
+					RegisterDecoderWithPriority(ft1, pc1, 1).
+					RegisterTypeDecoder(ft1, pc2)
+				got := rb.typeDecoders[ft1]
+				if !cmp.Equal(got, ValueDecoder(pc2), cmp.AllowUnexported(fakeCodec{})) {
+					t.Errorf("Codecs did not match. got %#v; want %#v", got, pc2)
+				}
+
+				// The plain registration has no priority, so a later registration with a lower priority replaces it.
+				rb.RegisterDecoderWithPriority(ft1, pc3, -1)
+				got = rb.typeDecoders[ft1]
+				if !cmp.Equal(got, ValueDecoder(pc3), cmp.AllowUnexported(fakeCodec{})) {
+					t.Errorf("Codecs did not match. got %#v; want %#v", got, pc3)
+				}
+			})
+		})
 		t.Run("type", func(t *testing.T) {
 			ft1, ft2, ft4 := fakeType1{}, fakeType2{}, fakeType4{}
 			rb := NewRegistryBuilder().
@@ -9,10 +9,12 @@ package bson
 import (
 	"bytes"
 	"encoding/json"
+	"sort"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 const defaultDstCap = 256
@@ -45,6 +47,50 @@ func Marshal(val interface{}) ([]byte, error) {
 	return MarshalWithRegistry(DefaultRegistry, val)
 }
 
+// MarshalCanonical returns the BSON encoding of val as a BSON document with the keys of every document sorted in
+// ascending byte order at all nesting levels, including documents embedded in arrays. The order of array elements is
+// preserved. This is useful when the encoded bytes are compared or hashed and must not depend on map iteration order
+// or struct field order.
+//
+// MarshalCanonical uses the default registry created by NewRegistry to marshal val.
+func MarshalCanonical(val interface{}) ([]byte, error) {
+	b, err := Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	return appendCanonicalDocument(make([]byte, 0, len(b)), bsoncore.Document(b), true)
+}
+
+// appendCanonicalDocument appends a copy of doc to dst with each embedded document and array recursively
+// canonicalized. If sortKeys is true, the elements of doc itself are sorted by key.
+func appendCanonicalDocument(dst []byte, doc bsoncore.Document, sortKeys bool) ([]byte, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+	if sortKeys {
+		sort.SliceStable(elems, func(i, j int) bool {
+			return bytes.Compare(elems[i].KeyBytes(), elems[j].KeyBytes()) < 0
+		})
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	for _, elem := range elems {
+		val := elem.Value()
+		switch val.Type {
+		case bsontype.EmbeddedDocument, bsontype.Array:
+			dst = bsoncore.AppendHeader(dst, val.Type, elem.Key())
+			dst, err = appendCanonicalDocument(dst, val.Data, val.Type == bsontype.EmbeddedDocument)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			dst = append(dst, elem...)
+		}
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
 // MarshalAppend will encode val as a BSON document and append the bytes to dst. If dst is not large enough to hold the
 // bytes, it will be grown. If val is not a type that can be transformed into a document, MarshalValueAppend should be
 // used instead.
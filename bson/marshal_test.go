@@ -217,6 +217,38 @@ func TestMarshal_roundtripFromDoc(t *testing.T) {
 	}
 }
 
+func TestMarshalCanonical(t *testing.T) {
+	t.Run("sorts keys at all levels", func(t *testing.T) {
+		val := D{
+			{"z", int32(1)},
+			{"a", D{{"y", "foo"}, {"b", "bar"}}},
+			{"m", A{D{{"q", int32(2)}, {"c", int32(3)}}, "x", A{D{{"k", true}, {"d", false}}}}},
+		}
+		want := D{
+			{"a", D{{"b", "bar"}, {"y", "foo"}}},
+			{"m", A{D{{"c", int32(3)}, {"q", int32(2)}}, "x", A{D{{"d", false}, {"k", true}}}}},
+			{"z", int32(1)},
+		}
+
+		got, err := MarshalCanonical(val)
+		require.NoError(t, err)
+		wantBytes, err := Marshal(want)
+		require.NoError(t, err)
+		assert.Equal(t, wantBytes, got, "expected %v, got %v", Raw(wantBytes), Raw(got))
+	})
+	t.Run("independent of input order", func(t *testing.T) {
+		first, err := MarshalCanonical(D{{"b", int32(1)}, {"a", D{{"d", int32(2)}, {"c", int32(3)}}}})
+		require.NoError(t, err)
+		second, err := MarshalCanonical(D{{"a", D{{"c", int32(3)}, {"d", int32(2)}}}, {"b", int32(1)}})
+		require.NoError(t, err)
+		assert.Equal(t, first, second, "expected %v, got %v", Raw(first), Raw(second))
+	})
+	t.Run("error", func(t *testing.T) {
+		_, err := MarshalCanonical(int32(1))
+		assert.NotNil(t, err, "expected error, got nil")
+	})
+}
+
 func TestCachingEncodersNotSharedAcrossRegistries(t *testing.T) {
 	// Encoders that have caches for recursive encoder lookup should not be shared across Registry instances. Otherwise,
 	// the first EncodeValue call would cache an encoder and a subsequent call would see that encoder even if a
@@ -25,6 +25,10 @@ import (
 // ErrInvalidHex indicates that a hex string cannot be converted to an ObjectID.
 var ErrInvalidHex = errors.New("the provided hex string is not a valid ObjectID")
 
+// ErrInvalidObjectIDLength indicates that a byte slice cannot be converted to an ObjectID because it is not 12 bytes
+// long.
+var ErrInvalidObjectIDLength = errors.New("the provided byte slice is not 12 bytes long")
+
 // ObjectID is the BSON ObjectID type.
 type ObjectID [12]byte
 
@@ -91,6 +95,18 @@ func ObjectIDFromHex(s string) (ObjectID, error) {
 	return oid, nil
 }
 
+// ObjectIDFromBytes creates a new ObjectID from a byte slice. It returns ErrInvalidObjectIDLength if the slice is not
+// exactly 12 bytes long. The bytes are copied, so b may be modified after ObjectIDFromBytes returns.
+func ObjectIDFromBytes(b []byte) (ObjectID, error) {
+	if len(b) != len(NilObjectID) {
+		return NilObjectID, ErrInvalidObjectIDLength
+	}
+
+	var oid ObjectID
+	copy(oid[:], b)
+	return oid, nil
+}
+
 // IsValidObjectID returns true if the provided hex string represents a valid ObjectID and false if not.
 func IsValidObjectID(s string) bool {
 	_, err := ObjectIDFromHex(s)
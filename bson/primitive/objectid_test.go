@@ -60,6 +60,23 @@ func TestFromHex_WrongLength(t *testing.T) {
 	require.Equal(t, ErrInvalidHex, err)
 }
 
+func TestFromBytes_RoundTrip(t *testing.T) {
+	before := NewObjectID()
+	b := make([]byte, len(before))
+	copy(b, before[:])
+	after, err := ObjectIDFromBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	b[0]++
+	require.Equal(t, before, after)
+}
+
+func TestFromBytes_WrongLength(t *testing.T) {
+	_, err := ObjectIDFromBytes([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.Equal(t, ErrInvalidObjectIDLength, err)
+}
+
 func TestIsValidObjectID(t *testing.T) {
 	testCases := []struct {
 		givenID  string
@@ -11,6 +11,7 @@ package primitive // import "go.mongodb.org/mongo-driver/bson/primitive"
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -99,6 +100,54 @@ func (rp Regex) IsZero() bool {
 	return rp.Pattern == "" && rp.Options == ""
 }
 
+// MarshalJSON returns rp as a relaxed extended JSON regular expression of the form
+// {"$regex": <pattern>, "$options": <options>}.
+func (rp Regex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Regex   string `json:"$regex"`
+		Options string `json:"$options"`
+	}{rp.Pattern, rp.Options})
+}
+
+// UnmarshalJSON creates a primitive.Regex from a JSON document. The document can be a relaxed extended JSON regular
+// expression of the form {"$regex": <pattern>, "$options": <options>}, a canonical extended JSON regular expression of
+// the form {"$regularExpression": {"pattern": <pattern>, "options": <options>}}, or a document with Pattern and Options
+// fields as produced by encoding/json for earlier versions of this type. If b is "null", rp will be unchanged.
+func (rp *Regex) UnmarshalJSON(b []byte) error {
+	// Ignore "null" to keep parity with the standard library. Decoding a JSON null into a non-pointer Regex field
+	// will leave the field unchanged. For pointer values, encoding/json will set the pointer to nil and will not
+	// enter the UnmarshalJSON hook.
+	if string(b) == "null" {
+		return nil
+	}
+
+	var res struct {
+		Regex             *string `json:"$regex"`
+		Options           string  `json:"$options"`
+		RegularExpression *struct {
+			Pattern *string `json:"pattern"`
+			Options string  `json:"options"`
+		} `json:"$regularExpression"`
+		GoPattern *string `json:"Pattern"`
+		GoOptions string  `json:"Options"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return err
+	}
+
+	switch {
+	case res.Regex != nil:
+		*rp = Regex{Pattern: *res.Regex, Options: res.Options}
+	case res.RegularExpression != nil && res.RegularExpression.Pattern != nil:
+		*rp = Regex{Pattern: *res.RegularExpression.Pattern, Options: res.RegularExpression.Options}
+	case res.GoPattern != nil:
+		*rp = Regex{Pattern: *res.GoPattern, Options: res.GoOptions}
+	default:
+		return errors.New("not an extended JSON regular expression: expected key $regex or $regularExpression")
+	}
+	return nil
+}
+
 // DBPointer represents a BSON dbpointer value.
 type DBPointer struct {
 	DB      string
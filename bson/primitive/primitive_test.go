@@ -84,6 +84,56 @@ func TestRegexCompare(t *testing.T) {
 	}
 }
 
+func TestRegexJSON(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		b, err := json.Marshal(Regex{Pattern: "abc", Options: "i"})
+		require.NoError(t, err)
+		assert.Equal(t, `{"$regex":"abc","$options":"i"}`, string(b), "expected JSON %q, got %q",
+			`{"$regex":"abc","$options":"i"}`, string(b))
+	})
+	t.Run("unmarshal", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			json string
+			want Regex
+		}{
+			{"relaxed", `{"$regex":"abc","$options":"i"}`, Regex{Pattern: "abc", Options: "i"}},
+			{"relaxed without options", `{"$regex":"abc"}`, Regex{Pattern: "abc"}},
+			{"canonical", `{"$regularExpression":{"pattern":"abc","options":"mx"}}`, Regex{Pattern: "abc", Options: "mx"}},
+			{"struct", `{"Pattern":"abc","Options":"i"}`, Regex{Pattern: "abc", Options: "i"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var got Regex
+				err := json.Unmarshal([]byte(tc.json), &got)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got, "expected %v, got %v", tc.want, got)
+			})
+		}
+	})
+	t.Run("unmarshal null", func(t *testing.T) {
+		got := Regex{Pattern: "abc"}
+		err := json.Unmarshal([]byte("null"), &got)
+		require.NoError(t, err)
+		assert.Equal(t, Regex{Pattern: "abc"}, got, "expected Regex to be unchanged, got %v", got)
+	})
+	t.Run("unmarshal error", func(t *testing.T) {
+		for _, input := range []string{`{"foo":"bar"}`, `"abc"`, `{"$regularExpression":{"options":"i"}}`} {
+			var got Regex
+			err := json.Unmarshal([]byte(input), &got)
+			assert.NotNil(t, err, "expected error for %s, got nil", input)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		original := Regex{Pattern: `^a\.b$`, Options: "im"}
+		b, err := json.Marshal(original)
+		require.NoError(t, err)
+		var got Regex
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, original, got, "expected %v, got %v", original, got)
+	})
+}
+
 func TestDateTime(t *testing.T) {
 	t.Run("json", func(t *testing.T) {
 		t.Run("round trip", func(t *testing.T) {
@@ -3033,7 +3033,7 @@ func snapshotQueryPetExample(mt *mtest.T) error {
 	defer sess.EndSession(ctx)
 
 	var adoptablePetsCount int32
-	err = mongo.WithSession(ctx, sess, func(ctx mongo.SessionContext) error {
+	err = mongo.WithSessionContext(ctx, sess, func(ctx mongo.SessionContext) error {
 		// Count the adoptable cats
 		const adoptableCatsOutput = "adoptableCatsCount"
 		cursor, err := db.Collection("cats").Aggregate(ctx, mongo.Pipeline{
@@ -3097,7 +3097,7 @@ func snapshotQueryRetailExample(mt *mtest.T) error {
 	defer sess.EndSession(ctx)
 
 	var totalDailySales int32
-	err = mongo.WithSession(ctx, sess, func(ctx mongo.SessionContext) error {
+	err = mongo.WithSessionContext(ctx, sess, func(ctx mongo.SessionContext) error {
 		// Count the total daily sales
 		const totalDailySalesOutput = "totalDailySales"
 		cursor, err := db.Collection("sales").Aggregate(ctx, mongo.Pipeline{
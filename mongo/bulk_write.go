@@ -307,17 +307,21 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 
 		switch converted := model.(type) {
 		case *ReplaceOneModel:
+			checkDocument := ensureNoDollarKey
+			if converted.SkipOperatorCheck != nil && *converted.SkipOperatorCheck {
+				checkDocument = nil
+			}
 			doc, err = createUpdateDoc(converted.Filter, converted.Replacement, converted.Hint, nil, converted.Collation, converted.Upsert, false,
-				false, bw.collection.registry)
+				checkDocument, bw.collection.registry)
 			hasHint = hasHint || (converted.Hint != nil)
 		case *UpdateOneModel:
 			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, false,
-				true, bw.collection.registry)
+				ensureDollarKey, bw.collection.registry)
 			hasHint = hasHint || (converted.Hint != nil)
 			hasArrayFilters = hasArrayFilters || (converted.ArrayFilters != nil)
 		case *UpdateManyModel:
 			doc, err = createUpdateDoc(converted.Filter, converted.Update, converted.Hint, converted.ArrayFilters, converted.Collation, converted.Upsert, true,
-				true, bw.collection.registry)
+				ensureDollarKey, bw.collection.registry)
 			hasHint = hasHint || (converted.Hint != nil)
 			hasArrayFilters = hasArrayFilters || (converted.ArrayFilters != nil)
 		}
@@ -373,7 +377,7 @@ func createUpdateDoc(
 	collation *options.Collation,
 	upsert *bool,
 	multi bool,
-	checkDollarKey bool,
+	checkDocument func(bsoncore.Document) error,
 	registry *bsoncodec.Registry,
 ) (bsoncore.Document, error) {
 	f, err := transformBsoncoreDocument(registry, filter, true, "filter")
@@ -384,7 +388,7 @@ func createUpdateDoc(
 	uidx, updateDoc := bsoncore.AppendDocumentStart(nil)
 	updateDoc = bsoncore.AppendDocumentElement(updateDoc, "q", f)
 
-	u, err := transformUpdateValue(registry, update, checkDollarKey)
+	u, err := transformUpdateValue(registry, update, checkDocument)
 	if err != nil {
 		return nil, err
 	}
@@ -120,11 +120,12 @@ func (*DeleteManyModel) writeModel() {}
 
 // ReplaceOneModel is used to replace at most one document in a BulkWrite operation.
 type ReplaceOneModel struct {
-	Collation   *options.Collation
-	Upsert      *bool
-	Filter      interface{}
-	Replacement interface{}
-	Hint        interface{}
+	Collation         *options.Collation
+	Upsert            *bool
+	Filter            interface{}
+	Replacement       interface{}
+	Hint              interface{}
+	SkipOperatorCheck *bool
 }
 
 // NewReplaceOneModel creates a new ReplaceOneModel.
@@ -173,6 +174,14 @@ func (rom *ReplaceOneModel) SetUpsert(upsert bool) *ReplaceOneModel {
 	return rom
 }
 
+// SetSkipOperatorCheck specifies whether the driver should skip checking the replacement document for top-level keys
+// beginning with '$' before sending it to the server. By default, the driver returns an error wrapping
+// ErrDocumentContainsUpdateOperators if any such keys are found.
+func (rom *ReplaceOneModel) SetSkipOperatorCheck(skip bool) *ReplaceOneModel {
+	rom.SkipOperatorCheck = &skip
+	return rom
+}
+
 func (*ReplaceOneModel) writeModel() {}
 
 // UpdateOneModel is used to update at most one document in a BulkWrite operation.
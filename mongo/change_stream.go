@@ -7,17 +7,23 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -33,11 +39,53 @@ var (
 	ErrMissingResumeToken = errors.New("cannot provide resume functionality when the resume token is missing")
 	// ErrNilCursor indicates that the underlying cursor for the change stream is nil.
 	ErrNilCursor = errors.New("cursor is nil")
+	// ErrBackfillTooSlow indicates that a change stream did not catch up to the time it was opened within the
+	// configured MaxBackfillDuration.
+	ErrBackfillTooSlow = errors.New("change stream did not catch up within the maximum backfill duration")
+	// ErrFullDocumentNotSupported indicates that the server does not support the FullDocument or
+	// FullDocumentBeforeChange mode requested for a change stream.
+	ErrFullDocumentNotSupported = errors.New("full document mode is not supported by the server")
+	// ErrMissingFullDocument indicates that an update event did not include a fullDocument field even though the
+	// change stream was opened with FullDocument set to options.UpdateLookup and AllowMissingDocuments set to false.
+	ErrMissingFullDocument = errors.New("update event does not contain a fullDocument field")
+	// ErrClusterTimeRegression indicates that a change stream event had an earlier clusterTime than the event before
+	// it, which means that events may have been lost.
+	ErrClusterTimeRegression = errors.New("change stream event clusterTime is earlier than the previous event")
+	// ErrPipelineTooLarge indicates that the aggregation pipeline for a change stream is larger than the maximum BSON
+	// document size of the server, so the aggregate command could not be sent.
+	ErrPipelineTooLarge = errors.New("change stream pipeline is too large")
+	// ErrPreImageUnavailable indicates that the server could not return the pre-image of an event for a change stream
+	// opened with FullDocumentBeforeChange set to options.Required. This usually means that pre-images are not enabled
+	// for the collection or have expired. The server reports a missing post-image with the same error code, so if
+	// FullDocument is also options.Required, this error can also mean that a post-image was not available.
+	ErrPreImageUnavailable = errors.New("pre-image is not available for change stream event")
+	// ErrInterrupted indicates that a call to Next or TryNext was stopped by ChangeStream.Interrupt. The change stream
+	// can continue to be used after this error is returned.
+	ErrInterrupted = errors.New("change stream was interrupted")
+	// ErrInvalidChangeStreamStage indicates that the pipeline for a change stream contains a stage that cannot be used
+	// in a change stream. The error returned by the driver wraps this error and includes the offending stage.
+	ErrInvalidChangeStreamStage = errors.New("stage is not allowed in a change stream pipeline")
 
 	minResumableLabelWireVersion int32 = 9 // Wire version at which the server includes the resumable error label
 	networkErrorLabel                  = "NetworkError"
 	resumableErrorLabel                = "ResumableChangeStreamError"
-	errorCursorNotFound          int32 = 43 // CursorNotFound error code
+	errorCursorNotFound          int32 = 43  // CursorNotFound error code
+	errorNoMatchingDocument      int32 = 47  // NoMatchingDocument error code
+	errorHistoryLost             int32 = 286 // ChangeStreamHistoryLost error code
+	decodeErrorsBufferSize             = 64  // Capacity of the channel returned by ChangeStream.DecodeErrors
+	maxRestartsPerNext                 = 3   // Restarts of a WatchForever stream allowed in one call to Next or TryNext
+	splitLargeEventStage               = "$changeStreamSplitLargeEvent"
+
+	// The time to wait before the first retry of a getMore when the GetMoreRetries option is set. The wait doubles for
+	// each following retry.
+	getMoreRetryBackoff = 50 * time.Millisecond
+
+	// Minimum wire versions for the features reported by ChangeStream.SupportedFeatures.
+	minStartAtOperationTimeWireVersion int32 = 7  // 4.0
+	minStartAfterWireVersion           int32 = 8  // 4.2
+	minPreImagesWireVersion            int32 = 17 // 6.0
+	minExpandedEventsWireVersion       int32 = 17 // 6.0
+	minSplitLargeEventsWireVersion     int32 = 21 // 7.0
 
 	// Allowlist of error codes that are considered resumable.
 	resumableChangeStreamErrors = map[int32]struct{}{
@@ -59,6 +107,69 @@ var (
 		234:   {}, // RetryChangeStream
 		133:   {}, // FailedToSatisfyReadPreference
 	}
+
+	// Error codes returned by the server when a change stream cannot be started from the requested resume token.
+	resumeTokenNotFoundErrors = map[int32]struct{}{
+		260: {}, // InvalidResumeToken
+		280: {}, // ChangeStreamFatalError
+		286: {}, // ChangeStreamHistoryLost
+	}
+
+	// Stages that are allowed in a change stream pipeline. Other stages are rejected when StrictPipelineValidation is
+	// set.
+	changeStreamAllowedStages = map[string]struct{}{
+		"$addFields":         {},
+		"$match":             {},
+		"$project":           {},
+		"$redact":            {},
+		"$replaceRoot":       {},
+		"$replaceWith":       {},
+		"$set":               {},
+		"$unset":             {},
+		splitLargeEventStage: {},
+	}
+
+	// Stages that are known to be incompatible with change streams. These are always rejected.
+	changeStreamDisallowedStages = map[string]struct{}{
+		"$bucket":       {},
+		"$bucketAuto":   {},
+		"$changeStream": {},
+		"$count":        {},
+		"$facet":        {},
+		"$geoNear":      {},
+		"$graphLookup":  {},
+		"$group":        {},
+		"$limit":        {},
+		"$lookup":       {},
+		"$merge":        {},
+		"$out":          {},
+		"$sample":       {},
+		"$skip":         {},
+		"$sort":         {},
+		"$sortByCount":  {},
+		"$unionWith":    {},
+		"$unwind":       {},
+	}
+
+	// Minimum wire versions required for each fullDocument and fullDocumentBeforeChange mode. Modes that are not
+	// listed are supported by every server version that supports change streams.
+	fullDocumentMinWireVersions = map[options.FullDocument]int32{
+		options.UpdateLookup:  6,                       // 3.6
+		options.WhenAvailable: minPreImagesWireVersion, // 6.0
+		options.Required:      minPreImagesWireVersion, // 6.0
+	}
+
+	// Minimum wire versions required for each $changeStream option. Options that are not listed are supported by every
+	// server version that supports change streams.
+	changeStreamOptionMinWireVersions = map[string]int32{
+		"startAtOperationTime": minStartAtOperationTimeWireVersion,
+		"startAfter":           minStartAfterWireVersion,
+		"showExpandedEvents":   minExpandedEventsWireVersion,
+		"showSystemEvents":     17, // 6.0
+	}
+
+	// Databases excluded from client change streams by the ExcludeSystemNamespaces option by default.
+	defaultSystemDatabases = []string{"admin", "config", "local"}
 )
 
 // ChangeStream is used to iterate over a stream of events. Each event can be decoded into a Go type via the Decode
@@ -86,6 +197,158 @@ type ChangeStream struct {
 	selector        description.ServerSelector
 	operationTime   *primitive.Timestamp
 	wireVersion     *description.VersionRange
+
+	openedAt         time.Time
+	backfillDeadline time.Time
+	backfillDone     bool
+
+	lastClusterTime primitive.Timestamp
+
+	// The resume token from before the current event was returned, used by Unread.
+	prevResumeToken bson.Raw
+	canUnread       bool
+
+	cursorClosedNotified bool
+
+	// The resume option in the most recently built $changeStream stage, and the one sent in the aggregate that opened
+	// the change stream, recorded for InitialResumeMode.
+	builtResumeMode    string
+	builtResumeToken   bson.Raw
+	initialResumeMode  string
+	initialResumeToken bson.Raw
+
+	// The channel returned by DecodeErrors and whether it has been closed. These are guarded by decodeErrorsMu so that
+	// NextDecode never sends on the channel after Close has closed it. The sizes of the events and resume tokens of the
+	// most recently sent errors are kept for MemoryFootprint.
+	decodeErrorsMu     sync.Mutex
+	decodeErrors       chan error
+	decodeErrorsClosed bool
+	decodeErrorSizes   []int
+	decodeErrorsSent   int
+
+	// The source used to choose which events are returned when SampleRate is set. Created on first use.
+	sampler *rand.Rand
+
+	// Whether err was caused by the context passed to Next or TryNext expiring.
+	contextErr bool
+
+	// The labels of the most recent error returned by an aggregate or getMore, used by LastErrorLabels.
+	lastErrorLabels []string
+
+	// Fragments of a split event collected so far when SplitLargeChanges is set.
+	splitFragments []bsoncore.Document
+
+	// The position and number of the user-supplied stages in pipelineSlice. ResumePipelineFunc replaces these stages
+	// when the change stream resumes.
+	userStagesStart int
+	userStagesLen   int
+
+	// now returns the current time. If nil, time.Now is used. Tests can replace it to control the clock.
+	now         func() time.Time
+	lastEventAt time.Time
+
+	// The resume token of the latest event that has been processed by ParallelEach along with every event before it.
+	// It is guarded by committedMu because CommittedResumeToken can be called from the goroutines running fn.
+	committedMu    sync.Mutex
+	committedToken bson.Raw
+
+	// The address of the server that the change stream was most recently opened or resumed on, and whether the most
+	// recent resume selected a different server than the one before it.
+	serverAddr         address.Address
+	resumedToNewServer bool
+
+	// Whether the read concern of the aggregate that opened the current server cursor included afterClusterTime.
+	afterClusterTime bool
+
+	// The function that cancels the context used by the getMore in progress, and whether it was called by Interrupt.
+	// These are guarded by interruptMu because Interrupt can be called from other goroutines.
+	interruptMu     sync.Mutex
+	interruptCancel context.CancelFunc
+	interrupted     bool
+
+	// Whether the most recent getMore of the current call to Next or TryNext was stopped by Interrupt.
+	getMoreInterrupted bool
+
+	// The function passed to Collection.WatchForever. If it is set, the change stream restarts from the current time
+	// instead of returning an error when its resume token is lost.
+	onRestart func(time.Time)
+
+	// The number of events returned by Next and TryNext, not counting events that were pushed back by Unread.
+	sequence uint64
+}
+
+// Namespace identifies a collection by the name of its database and the name of the collection.
+type Namespace struct {
+	DB   string
+	Coll string
+}
+
+// WatchCollections returns a single change stream for all changes to the collections identified by namespaces. If all
+// of the namespaces are in the same database, the change stream is opened on that database as if by Database.Watch.
+// Otherwise, it is opened on the whole deployment as if by Client.Watch, which requires read access to every
+// database. In both cases, a $match stage that only lets through events on the given collections is prepended to the
+// pipeline parameter. Events that do not belong to a single collection, such as dropDatabase, are filtered out.
+//
+// The namespaces parameter must contain at least one namespace, and every namespace must have both a database and a
+// collection name. The pipeline and opts parameters behave as they do for Collection.Watch.
+func WatchCollections(ctx context.Context, client *Client, namespaces []Namespace, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
+	if client == nil {
+		return nil, errors.New("client cannot be nil")
+	}
+	if len(namespaces) == 0 {
+		return nil, errors.New("at least one namespace must be provided")
+	}
+
+	// Group the collection names by database, keeping the databases in the order they were first seen.
+	var dbs []string
+	colls := make(map[string]bson.A)
+	for _, ns := range namespaces {
+		if ns.DB == "" || ns.Coll == "" {
+			return nil, fmt.Errorf("namespace %+v must have both a database and a collection name", ns)
+		}
+		if _, ok := colls[ns.DB]; !ok {
+			dbs = append(dbs, ns.DB)
+		}
+		colls[ns.DB] = append(colls[ns.DB], ns.Coll)
+	}
+
+	var match bson.D
+	if len(dbs) == 1 {
+		match = bson.D{{"ns.coll", bson.D{{"$in", colls[dbs[0]]}}}}
+	} else {
+		filters := make(bson.A, 0, len(dbs))
+		for _, db := range dbs {
+			filters = append(filters, bson.D{{"ns.db", db}, {"ns.coll", bson.D{{"$in", colls[db]}}}})
+		}
+		match = bson.D{{"$or", filters}}
+	}
+
+	combined, err := prependPipelineStages(bson.A{bson.D{{"$match", match}}}, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(dbs) == 1 {
+		return client.Database(dbs[0]).Watch(ctx, combined, opts...)
+	}
+	return client.Watch(ctx, combined, opts...)
+}
+
+// prependPipelineStages returns a pipeline consisting of stages followed by the stages of pipeline, which must be a
+// slice of stage documents.
+func prependPipelineStages(stages bson.A, pipeline interface{}) (bson.A, error) {
+	val := reflect.ValueOf(pipeline)
+	if !val.IsValid() || val.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("can only transform slices and arrays into aggregation pipelines, but got %v", val.Kind())
+	}
+
+	combined := make(bson.A, 0, len(stages)+val.Len())
+	combined = append(combined, stages...)
+	for i := 0; i < val.Len(); i++ {
+		combined = append(combined, val.Index(i).Interface())
+	}
+	return combined, nil
 }
 
 type changeStreamConfig struct {
@@ -97,6 +360,7 @@ type changeStreamConfig struct {
 	collectionName string
 	databaseName   string
 	crypt          driver.Crypt
+	onRestart      func(time.Time)
 }
 
 func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline interface{},
@@ -115,6 +379,21 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 			description.LatencySelector(config.client.localThreshold),
 		}),
 		cursorOptions: config.client.createBaseCursorOptions(),
+		openedAt:      time.Now(),
+		onRestart:     config.onRestart,
+		decodeErrors:  make(chan error, decodeErrorsBufferSize),
+	}
+
+	readConcern := config.readConcern
+	if rc := cs.options.ReadConcern; rc != nil {
+		switch level := rc.GetLevel(); level {
+		case readconcern.Linearizable().GetLevel(), readconcern.Snapshot().GetLevel():
+			return nil, fmt.Errorf("read concern level %q is not supported by change streams", level)
+		}
+		readConcern = rc
+	}
+	if rate := cs.options.SampleRate; rate != nil && (*rate < 0 || *rate > 1) {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1, got %v", *rate)
 	}
 
 	cs.sess = sessionFromContext(ctx)
@@ -128,13 +407,42 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		closeImplicitSession(cs.sess)
 		return nil, cs.Err()
 	}
+	if cp := cs.options.StartFromCheckpoint; cp != nil {
+		if cs.options.ResumeAfter != nil || cs.options.StartAfter != nil || cs.options.StartAtOperationTime != nil ||
+			cs.options.StartAfterOperation != nil {
+			closeImplicitSession(cs.sess)
+			return nil, errors.New("StartFromCheckpoint cannot be combined with ResumeAfter, StartAfter, " +
+				"StartAtOperationTime, or StartAfterOperation")
+		}
+		if cp.ResumeToken != nil {
+			cs.options.SetResumeAfter(cp.ResumeToken)
+		} else if cp.OperationTime != nil {
+			cs.options.SetStartAtOperationTime(cp.OperationTime)
+		}
+	}
+	if pin := cs.options.StartAfterOperation; pin != nil {
+		if cs.options.ResumeAfter != nil || cs.options.StartAfter != nil {
+			closeImplicitSession(cs.sess)
+			return nil, errors.New("StartAfterOperation cannot be combined with ResumeAfter or StartAfter")
+		}
+		if start := cs.options.StartAtOperationTime; start == nil || primitive.CompareTimestamp(*start, *pin) < 0 {
+			cs.options.SetStartAtOperationTime(pin)
+		}
+		if cs.sess != nil {
+			_ = cs.sess.AdvanceOperationTime(pin)
+		}
+	}
 
 	cs.aggregate = operation.NewAggregate(nil).
-		ReadPreference(config.readPreference).ReadConcern(config.readConcern).
-		Deployment(cs.client.deployment).ClusterClock(cs.client.clock).
+		ReadPreference(config.readPreference).ReadConcern(readConcern).
+		Deployment(cs.deployment()).ClusterClock(cs.client.clock).
 		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
 		ServerAPI(cs.client.serverAPI).Crypt(config.crypt).Timeout(cs.client.timeout)
 
+	if cs.options.CommandMonitor != nil {
+		cs.aggregate.CommandMonitor(cs.options.CommandMonitor)
+		cs.cursorOptions.CommandMonitor = cs.options.CommandMonitor
+	}
 	if cs.options.Collation != nil {
 		cs.aggregate.Collation(bsoncore.Document(cs.options.Collation.ToDocument()))
 	}
@@ -151,6 +459,9 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		cs.aggregate.BatchSize(*cs.options.BatchSize)
 		cs.cursorOptions.BatchSize = *cs.options.BatchSize
 	}
+	if cs.options.MaxDocsPerGetMore != nil {
+		cs.cursorOptions.BatchSize = *cs.options.MaxDocsPerGetMore
+	}
 	if cs.options.MaxAwaitTime != nil {
 		cs.cursorOptions.MaxTimeMS = int64(*cs.options.MaxAwaitTime / time.Millisecond)
 	}
@@ -221,17 +532,151 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	pipelineArr, cs.err = cs.pipelineToBSON()
 	cs.aggregate.Pipeline(pipelineArr)
 
-	if cs.err = cs.executeOperation(ctx, false); cs.err != nil {
+	if cs.err = cs.executeOperation(ctx, false); cs.err != nil && cs.canUseFallbackOperationTime() {
+		cs.err = cs.executeFallbackOperation(ctx)
+	}
+	if cs.err != nil && cs.canRestart() {
+		cs.err = cs.restart(ctx)
+	}
+	if cs.err != nil {
 		closeImplicitSession(cs.sess)
 		return nil, cs.Err()
 	}
 
+	// The stage used by the aggregate that opened the change stream reflects a fallback or restart, if there was one.
+	cs.initialResumeMode, cs.initialResumeToken = cs.builtResumeMode, cs.builtResumeToken
 	return cs, cs.Err()
 }
 
+// canUseFallbackOperationTime returns true if the initial aggregate failed because the server rejected the
+// resume token and a fallback operation time was configured.
+func (cs *ChangeStream) canUseFallbackOperationTime() bool {
+	if cs.options.FallbackStartAtOperationTime == nil ||
+		(cs.options.ResumeAfter == nil && cs.options.StartAfter == nil) {
+		return false
+	}
+
+	commandErr, ok := cs.err.(CommandError)
+	if !ok {
+		return false
+	}
+	_, notFound := resumeTokenNotFoundErrors[commandErr.Code]
+	return notFound
+}
+
+// executeFallbackOperation reruns the initial aggregate starting at the fallback operation time instead of the
+// rejected resume token. The aggregate is sent as a new change stream rather than a resume so that the fallback time
+// is sent as is. If the server does not support startAtOperationTime, an UnsupportedChangeStreamOptionError is
+// returned.
+func (cs *ChangeStream) executeFallbackOperation(ctx context.Context) error {
+	cs.err = nil
+	cs.resumeToken = nil
+	cs.options.SetStartAtOperationTime(cs.options.FallbackStartAtOperationTime)
+	cs.options.SetResumeAfter(nil)
+	cs.options.SetStartAfter(nil)
+
+	if cs.err = cs.rebuildPipeline(false); cs.err != nil {
+		return cs.Err()
+	}
+	return cs.executeOperation(ctx, false)
+}
+
+// canRestart returns true if the change stream was opened by Collection.WatchForever and the current error means that
+// its resume token is missing or has been lost.
+func (cs *ChangeStream) canRestart() bool {
+	if cs.onRestart == nil {
+		return false
+	}
+	if cs.err == ErrMissingResumeToken {
+		return true
+	}
+	commandErr, ok := cs.err.(CommandError)
+	return ok && commandErr.Code == errorHistoryLost
+}
+
+// restart reopens the change stream at the most recent operation time reported by the server, discarding the cached
+// resume token and any events remaining in the current batch. If the session has no operation time or the server does
+// not support startAtOperationTime, the change stream is opened without a start position, so the server starts it at
+// its current time. The function passed to Collection.WatchForever is called with the restart time before the
+// aggregate is sent.
+func (cs *ChangeStream) restart(ctx context.Context) error {
+	if cs.cursor != nil {
+		// The cursor may already be dead, so errors from closing it are ignored.
+		_ = cs.cursor.Close(ctx)
+	}
+	cs.err = nil
+	cs.batch = nil
+	cs.resumeToken = nil
+	cs.operationTime = nil
+
+	var opTime *primitive.Timestamp
+	if cs.sess != nil && cs.sess.OperationTime != nil && changeStreamFeaturesFor(cs.wireVersion).StartAtOperationTime {
+		opTime = &primitive.Timestamp{T: cs.sess.OperationTime.T, I: cs.sess.OperationTime.I}
+	}
+	cs.options.StartAtOperationTime = opTime
+	cs.options.SetResumeAfter(nil)
+	cs.options.SetStartAfter(nil)
+
+	// The restart time is only reported to onRestart, so the local clock is used if the server's time is unknown.
+	restartTime := cs.currentTime()
+	if opTime != nil {
+		restartTime = time.Unix(int64(opTime.T), 0)
+	}
+	cs.onRestart(restartTime)
+
+	if err := cs.rebuildPipeline(false); err != nil {
+		return err
+	}
+	return cs.executeOperation(ctx, false)
+}
+
+// recreate reopens the change stream from the position returned by the AutoRecreateOnInvalidate option after the
+// server has closed the cursor. It returns false if the option is not set or the function returned neither a resume
+// token nor an operation time, in which case the change stream stays closed.
+func (cs *ChangeStream) recreate(ctx context.Context) (bool, error) {
+	if cs.options.AutoRecreateOnInvalidate == nil {
+		return false, nil
+	}
+	token, opTime := cs.options.AutoRecreateOnInvalidate()
+	if token == nil && opTime == nil {
+		return false, nil
+	}
+
+	// The cursor is already closed on the server, so errors from closing it are ignored.
+	_ = cs.cursor.Close(ctx)
+	cs.err = nil
+	cs.batch = nil
+	cs.resumeToken = nil
+	cs.operationTime = nil
+
+	// The resume token of an invalidate event can only be used with startAfter, not resumeAfter, so the stream is
+	// opened as a new change stream rather than resumed.
+	cs.options.SetResumeAfter(nil)
+	cs.options.SetStartAfter(nil)
+	cs.options.SetStartAtOperationTime(nil)
+	if token != nil {
+		cs.options.SetStartAfter(token)
+	} else {
+		cs.options.SetStartAtOperationTime(opTime)
+	}
+	if cs.err = cs.rebuildPipeline(false); cs.err != nil {
+		return true, cs.Err()
+	}
+	return true, cs.executeOperation(ctx, false)
+}
+
+// deployment returns the deployment used for server selection, which is the Deployment option if it is set and the
+// Client's deployment otherwise.
+func (cs *ChangeStream) deployment() driver.Deployment {
+	if cs.options.Deployment != nil {
+		return cs.options.Deployment
+	}
+	return cs.client.deployment
+}
+
 func (cs *ChangeStream) createOperationDeployment(server driver.Server, connection driver.Connection) driver.Deployment {
 	return &changeStreamDeployment{
-		topologyKind: cs.client.deployment.Kind(),
+		topologyKind: cs.deployment().Kind(),
 		server:       server,
 		conn:         connection,
 	}
@@ -241,7 +686,7 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	var server driver.Server
 	var conn driver.Connection
 
-	if server, cs.err = cs.client.deployment.SelectServer(ctx, cs.selector); cs.err != nil {
+	if server, cs.err = cs.deployment().SelectServer(ctx, cs.selector); cs.err != nil {
 		return cs.Err()
 	}
 	if conn, cs.err = server.Connection(ctx); cs.err != nil {
@@ -249,28 +694,26 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	}
 	defer conn.Close()
 	cs.wireVersion = conn.Description().WireVersion
+	cs.recordServerAddress(conn.Description().Addr, resuming)
+	if cs.err = validateFullDocument(cs.options, cs.wireVersion); cs.err != nil {
+		return cs.Err()
+	}
 
 	cs.aggregate.Deployment(cs.createOperationDeployment(server, conn))
 
 	if resuming {
 		cs.replaceOptions(cs.wireVersion)
-
-		csOptDoc, err := cs.createPipelineOptionsDoc()
-		if err != nil {
-			return err
-		}
-		pipIdx, pipDoc := bsoncore.AppendDocumentStart(nil)
-		pipDoc = bsoncore.AppendDocumentElement(pipDoc, "$changeStream", csOptDoc)
-		if pipDoc, cs.err = bsoncore.AppendDocumentEnd(pipDoc, pipIdx); cs.err != nil {
-			return cs.Err()
-		}
-		cs.pipelineSlice[0] = pipDoc
-
-		var plArr bsoncore.Document
-		if plArr, cs.err = cs.pipelineToBSON(); cs.err != nil {
+		if cs.err = cs.rebuildPipeline(true); cs.err != nil {
 			return cs.Err()
 		}
-		cs.aggregate.Pipeline(plArr)
+	}
+	// The options are validated after they are replaced for a resume, because a resume can drop options that the
+	// server does not support, such as startAfter.
+	if cs.err = validatePipelineOptions(cs.options, cs.wireVersion); cs.err != nil {
+		return cs.Err()
+	}
+	if cs.err = cs.validatePipelineSize(conn.Description()); cs.err != nil {
+		return cs.Err()
 	}
 
 	// If no deadline is set on the passed-in context, cs.client.timeout is set, and context is not already
@@ -313,7 +756,7 @@ AggregateExecuteLoop:
 			// If error is retryable: subtract 1 from retries, redo server selection, checkout
 			// a connection, and restart loop.
 			retries--
-			server, err = cs.client.deployment.SelectServer(ctx, cs.selector)
+			server, err = cs.deployment().SelectServer(ctx, cs.selector)
 			if err != nil {
 				break AggregateExecuteLoop
 			}
@@ -337,20 +780,23 @@ AggregateExecuteLoop:
 	}
 	if err != nil {
 		cs.err = replaceErrors(err)
-		return cs.err
+		cs.recordErrorLabels()
+		return cs.classifyPreImageError(cs.err)
 	}
 
 	cr := cs.aggregate.ResultCursorResponse()
 	cr.Server = server
 
 	cs.cursor, cs.err = driver.NewBatchCursor(cr, cs.sess, cs.client.clock, cs.cursorOptions)
+	cs.cursorClosedNotified = false
+	cs.afterClusterTime = cs.aggregate.AfterClusterTime()
 	if cs.err = replaceErrors(cs.err); cs.err != nil {
 		return cs.Err()
 	}
 
 	cs.updatePbrtFromCommand()
 	if cs.options.StartAtOperationTime == nil && cs.options.ResumeAfter == nil &&
-		cs.options.StartAfter == nil && cs.wireVersion.Max >= 7 &&
+		cs.options.StartAfter == nil && changeStreamFeaturesFor(cs.wireVersion).StartAtOperationTime &&
 		cs.emptyBatch() && cs.resumeToken == nil {
 		cs.operationTime = cs.sess.OperationTime
 	}
@@ -358,11 +804,43 @@ AggregateExecuteLoop:
 	return cs.Err()
 }
 
+// rebuildPipeline replaces the $changeStream stage of the pipeline with one built from the current options and sets the
+// pipeline of the aggregate that opens the change stream. If resuming is true, the user-supplied stages are also
+// replaced using the ResumePipelineFunc option if it is set.
+func (cs *ChangeStream) rebuildPipeline(resuming bool) error {
+	csOptDoc, err := cs.createPipelineOptionsDoc()
+	if err != nil {
+		return err
+	}
+	pipIdx, pipDoc := bsoncore.AppendDocumentStart(nil)
+	pipDoc = bsoncore.AppendDocumentElement(pipDoc, "$changeStream", csOptDoc)
+	if pipDoc, err = bsoncore.AppendDocumentEnd(pipDoc, pipIdx); err != nil {
+		return err
+	}
+	cs.pipelineSlice[0] = pipDoc
+	if resuming && cs.options.ResumePipelineFunc != nil {
+		if err = cs.rewriteUserStages(); err != nil {
+			return err
+		}
+	}
+
+	plArr, err := cs.pipelineToBSON()
+	if err != nil {
+		return err
+	}
+	cs.aggregate.Pipeline(plArr)
+	return nil
+}
+
 // Updates the post batch resume token after a successful aggregate or getMore operation.
 func (cs *ChangeStream) updatePbrtFromCommand() {
 	// Only cache the pbrt if an empty batch was returned and a pbrt was included
 	if pbrt := cs.cursor.PostBatchResumeToken(); cs.emptyBatch() && pbrt != nil {
+		advanced := !bytes.Equal(cs.resumeToken, pbrt)
 		cs.resumeToken = bson.Raw(pbrt)
+		if advanced && cs.options.CheckpointOnEmptyBatch != nil {
+			cs.options.CheckpointOnEmptyBatch(cs.resumeToken)
+		}
 	}
 }
 
@@ -380,7 +858,10 @@ func (cs *ChangeStream) storeResumeToken() error {
 		var ok bool
 		tokenDoc, ok = cs.Current.Lookup("_id").DocumentOK()
 		if !ok {
-			_ = cs.Close(context.Background())
+			// A change stream opened by WatchForever restarts instead, so it is left open.
+			if cs.onRestart == nil {
+				_ = cs.Close(context.Background())
+			}
 			return ErrMissingResumeToken
 		}
 	}
@@ -411,19 +892,161 @@ func (cs *ChangeStream) buildPipelineSlice(pipeline interface{}) error {
 	}
 	cs.pipelineSlice = append(cs.pipelineSlice, csDoc)
 
+	if cs.streamType == ClientStream && cs.options.ExcludeSystemNamespaces != nil && *cs.options.ExcludeSystemNamespaces {
+		cs.pipelineSlice = append(cs.pipelineSlice, cs.createSystemNamespacesMatch())
+	}
+	if len(cs.options.OperationTypes) > 0 {
+		cs.pipelineSlice = append(cs.pipelineSlice, cs.createOperationTypesMatch())
+	}
+
+	cs.userStagesStart = len(cs.pipelineSlice)
+	cs.userStagesLen = val.Len()
 	for i := 0; i < val.Len(); i++ {
 		var elem []byte
 		elem, cs.err = transformBsoncoreDocument(cs.registry, val.Index(i).Interface(), true, fmt.Sprintf("pipeline stage :%v", i))
 		if cs.err != nil {
 			return cs.err
 		}
+		if cs.err = cs.validateStage(elem, i); cs.err != nil {
+			return cs.err
+		}
 
 		cs.pipelineSlice = append(cs.pipelineSlice, elem)
 	}
+	if cs.err = cs.validateSplitStage(cs.pipelineSlice[cs.userStagesStart:]); cs.err != nil {
+		return cs.err
+	}
+
+	// $changeStreamSplitLargeEvent must be the last stage in the pipeline.
+	if cs.splitLargeChanges() {
+		splitDoc := bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, splitLargeEventStage,
+			bsoncore.BuildDocument(nil)))
+		cs.pipelineSlice = append(cs.pipelineSlice, splitDoc)
+	}
 
 	return cs.err
 }
 
+// rewriteUserStages passes the user-supplied stages of the pipeline to the ResumePipelineFunc option and replaces
+// them with the stages it returns.
+func (cs *ChangeStream) rewriteUserStages() error {
+	prev := make([]bson.D, 0, cs.userStagesLen)
+	for _, stage := range cs.pipelineSlice[cs.userStagesStart : cs.userStagesStart+cs.userStagesLen] {
+		var d bson.D
+		if err := bson.UnmarshalWithRegistry(cs.registry, stage, &d); err != nil {
+			return err
+		}
+		prev = append(prev, d)
+	}
+
+	next := cs.options.ResumePipelineFunc(prev)
+	stages := make([]bsoncore.Document, 0, len(next))
+	for i, d := range next {
+		stage, err := transformBsoncoreDocument(cs.registry, d, true, fmt.Sprintf("pipeline stage :%v", i))
+		if err != nil {
+			return err
+		}
+		if err := cs.validateStage(stage, i); err != nil {
+			return err
+		}
+		stages = append(stages, stage)
+	}
+	if err := cs.validateSplitStage(stages); err != nil {
+		return err
+	}
+
+	end := cs.userStagesStart + cs.userStagesLen
+	pipelineSlice := make([]bsoncore.Document, 0, len(cs.pipelineSlice)-cs.userStagesLen+len(stages))
+	pipelineSlice = append(pipelineSlice, cs.pipelineSlice[:cs.userStagesStart]...)
+	pipelineSlice = append(pipelineSlice, stages...)
+	pipelineSlice = append(pipelineSlice, cs.pipelineSlice[end:]...)
+	cs.pipelineSlice = pipelineSlice
+	cs.userStagesLen = len(stages)
+	return nil
+}
+
+// validateStage returns an error wrapping ErrInvalidChangeStreamStage if the user-supplied pipeline stage at index i
+// cannot be used in a change stream. Stages that are not known to the driver are only rejected if the
+// StrictPipelineValidation option is set.
+func (cs *ChangeStream) validateStage(stage bsoncore.Document, i int) error {
+	elem, err := stage.IndexErr(0)
+	if err != nil {
+		// Let the server report empty stages.
+		return nil
+	}
+
+	name := elem.Key()
+	_, disallowed := changeStreamDisallowedStages[name]
+	if !disallowed && cs.options.StrictPipelineValidation != nil && *cs.options.StrictPipelineValidation {
+		_, allowed := changeStreamAllowedStages[name]
+		disallowed = !allowed
+	}
+	if disallowed {
+		return fmt.Errorf("%w: pipeline stage :%v: %q", ErrInvalidChangeStreamStage, i, name)
+	}
+	return nil
+}
+
+// validateSplitStage returns an error wrapping ErrInvalidChangeStreamStage if the user-supplied stages include a
+// $changeStreamSplitLargeEvent stage that the server would reject. The stage is rejected if the SplitLargeChanges
+// option is set, because the option already appends it, or if it is not the last stage of the pipeline.
+func (cs *ChangeStream) validateSplitStage(stages []bsoncore.Document) error {
+	for i, stage := range stages {
+		elem, err := stage.IndexErr(0)
+		if err != nil || elem.Key() != splitLargeEventStage {
+			continue
+		}
+
+		if cs.splitLargeChanges() {
+			return fmt.Errorf("%w: pipeline stage :%v: %q cannot be used with the SplitLargeChanges option, which "+
+				"adds it to the end of the pipeline", ErrInvalidChangeStreamStage, i, splitLargeEventStage)
+		}
+		if i != len(stages)-1 {
+			return fmt.Errorf("%w: pipeline stage :%v: %q must be the last stage in the pipeline",
+				ErrInvalidChangeStreamStage, i, splitLargeEventStage)
+		}
+	}
+	return nil
+}
+
+// splitLargeChanges returns true if the SplitLargeChanges option is set to true.
+func (cs *ChangeStream) splitLargeChanges() bool {
+	return cs.options.SplitLargeChanges != nil && *cs.options.SplitLargeChanges
+}
+
+// createSystemNamespacesMatch returns a $match stage that excludes events from the databases specified by the
+// SystemDatabases option.
+func (cs *ChangeStream) createSystemNamespacesMatch() bsoncore.Document {
+	dbs := defaultSystemDatabases
+	if cs.options.SystemDatabases != nil {
+		dbs = cs.options.SystemDatabases
+	}
+
+	arrIdx, arr := bsoncore.AppendArrayStart(nil)
+	for i, db := range dbs {
+		arr = bsoncore.AppendStringElement(arr, strconv.Itoa(i), db)
+	}
+	arr, _ = bsoncore.AppendArrayEnd(arr, arrIdx)
+
+	nin := bsoncore.BuildDocument(nil, bsoncore.AppendArrayElement(nil, "$nin", arr))
+	match := bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "ns.db", nin))
+	return bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "$match", match))
+}
+
+// createOperationTypesMatch returns a $match stage that only allows events whose operationType is one of those
+// specified by the OperationTypes option.
+func (cs *ChangeStream) createOperationTypesMatch() bsoncore.Document {
+	arrIdx, arr := bsoncore.AppendArrayStart(nil)
+	for i, opType := range cs.options.OperationTypes {
+		arr = bsoncore.AppendStringElement(arr, strconv.Itoa(i), string(opType))
+	}
+	arr, _ = bsoncore.AppendArrayEnd(arr, arrIdx)
+
+	in := bsoncore.BuildDocument(nil, bsoncore.AppendArrayElement(nil, "$in", arr))
+	match := bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "operationType", in))
+	return bsoncore.BuildDocument(nil, bsoncore.AppendDocumentElement(nil, "$match", match))
+}
+
 func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 	plDocIdx, plDoc := bsoncore.AppendDocumentStart(nil)
 
@@ -438,9 +1061,15 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 	}
 
 	if cs.options.FullDocumentBeforeChange != nil {
-		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange", string(*cs.options.FullDocumentBeforeChange))
+		fdbc := *cs.options.FullDocumentBeforeChange
+		if cs.skipMissingPreImage() {
+			// Missing pre-images are detected client-side so the affected events can be skipped.
+			fdbc = options.WhenAvailable
+		}
+		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange", string(fdbc))
 	}
 
+	resumeMode, resumeToken := "none", bson.Raw(nil)
 	if cs.options.ResumeAfter != nil {
 		var raDoc bsoncore.Document
 		raDoc, cs.err = transformBsoncoreDocument(cs.registry, cs.options.ResumeAfter, true, "resumeAfter")
@@ -449,12 +1078,17 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 		}
 
 		plDoc = bsoncore.AppendDocumentElement(plDoc, "resumeAfter", raDoc)
+		resumeMode, resumeToken = "resumeAfter", bson.Raw(raDoc)
 	}
 
 	if cs.options.ShowExpandedEvents != nil {
 		plDoc = bsoncore.AppendBooleanElement(plDoc, "showExpandedEvents", *cs.options.ShowExpandedEvents)
 	}
 
+	if cs.options.ShowSystemEvents != nil {
+		plDoc = bsoncore.AppendBooleanElement(plDoc, "showSystemEvents", *cs.options.ShowSystemEvents)
+	}
+
 	if cs.options.StartAfter != nil {
 		var saDoc bsoncore.Document
 		saDoc, cs.err = transformBsoncoreDocument(cs.registry, cs.options.StartAfter, true, "startAfter")
@@ -463,11 +1097,20 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 		}
 
 		plDoc = bsoncore.AppendDocumentElement(plDoc, "startAfter", saDoc)
+		if resumeToken == nil {
+			resumeMode, resumeToken = "startAfter", bson.Raw(saDoc)
+		}
 	}
 
 	if cs.options.StartAtOperationTime != nil {
 		plDoc = bsoncore.AppendTimestampElement(plDoc, "startAtOperationTime", cs.options.StartAtOperationTime.T, cs.options.StartAtOperationTime.I)
+		if resumeToken == nil {
+			resumeMode, resumeToken = "startAtOperationTime", bson.Raw(bsoncore.NewDocumentBuilder().
+				AppendTimestamp("startAtOperationTime", cs.options.StartAtOperationTime.T, cs.options.StartAtOperationTime.I).
+				Build())
+		}
 	}
+	cs.builtResumeMode, cs.builtResumeToken = resumeMode, resumeToken
 
 	// Append custom pipeline options.
 	for optionName, optionValue := range cs.pipelineOptions {
@@ -492,6 +1135,75 @@ func (cs *ChangeStream) pipelineToBSON() (bsoncore.Document, error) {
 	return pipelineArr, cs.err
 }
 
+// validatePipelineSize returns an error wrapping ErrPipelineTooLarge if the pipeline cannot fit in an aggregate command
+// sent to a server with the given description. The pipeline is part of the command document, so it must not be larger
+// than the server's maximum BSON document size, which is also well below its maximum message size.
+func (cs *ChangeStream) validatePipelineSize(desc description.Server) error {
+	if desc.MaxDocumentSize == 0 {
+		return nil
+	}
+	pipeline, err := cs.pipelineToBSON()
+	if err != nil {
+		return err
+	}
+	if size := len(pipeline); size > int(desc.MaxDocumentSize) {
+		return fmt.Errorf("%w: pipeline is %d bytes, but the maximum BSON document size of %v is %d bytes",
+			ErrPipelineTooLarge, size, desc.Addr, desc.MaxDocumentSize)
+	}
+	return nil
+}
+
+// validateFullDocument returns an error if the server does not support the fullDocument or fullDocumentBeforeChange
+// mode requested in opts. Older servers may otherwise silently omit the requested document from each event.
+func validateFullDocument(opts *options.ChangeStreamOptions, wireVersion *description.VersionRange) error {
+	if wireVersion == nil {
+		return nil
+	}
+	check := func(name string, fd *options.FullDocument) error {
+		if fd == nil {
+			return nil
+		}
+		minWireVersion, ok := fullDocumentMinWireVersions[*fd]
+		if !ok || wireVersion.Max >= minWireVersion {
+			return nil
+		}
+		return fmt.Errorf("%w: %s %q requires wire version %d, but the server's maximum wire version is %d",
+			ErrFullDocumentNotSupported, name, *fd, minWireVersion, wireVersion.Max)
+	}
+
+	if err := check("fullDocument", opts.FullDocument); err != nil {
+		return err
+	}
+	return check("fullDocumentBeforeChange", opts.FullDocumentBeforeChange)
+}
+
+// validatePipelineOptions returns an UnsupportedChangeStreamOptionError if opts sets a $changeStream option that is not
+// supported by a server with the given wire version. Options passed through CustomPipeline are not checked.
+func validatePipelineOptions(opts *options.ChangeStreamOptions, wireVersion *description.VersionRange) error {
+	if wireVersion == nil {
+		return nil
+	}
+	pipelineOptions := []struct {
+		name string
+		set  bool
+	}{
+		{"startAtOperationTime", opts.StartAtOperationTime != nil},
+		{"startAfter", opts.StartAfter != nil},
+		{"showExpandedEvents", opts.ShowExpandedEvents != nil},
+		{"showSystemEvents", opts.ShowSystemEvents != nil},
+	}
+	for _, opt := range pipelineOptions {
+		if minWireVersion := changeStreamOptionMinWireVersions[opt.name]; opt.set && wireVersion.Max < minWireVersion {
+			return UnsupportedChangeStreamOptionError{
+				Option:         opt.name,
+				MinWireVersion: minWireVersion,
+				Have:           wireVersion.Max,
+			}
+		}
+	}
+	return nil
+}
+
 func (cs *ChangeStream) replaceOptions(wireVersion *description.VersionRange) {
 	// Cached resume token: use the resume token as the resumeAfter option and set no other resume options
 	if cs.resumeToken != nil {
@@ -503,12 +1215,19 @@ func (cs *ChangeStream) replaceOptions(wireVersion *description.VersionRange) {
 
 	// No cached resume token but cached operation time: use the operation time as the startAtOperationTime option and
 	// set no other resume options
-	if (cs.sess.OperationTime != nil || cs.options.StartAtOperationTime != nil) && wireVersion.Max >= 7 {
+	if (cs.sess.OperationTime != nil || cs.options.StartAtOperationTime != nil) &&
+		changeStreamFeaturesFor(wireVersion).StartAtOperationTime {
 		opTime := cs.options.StartAtOperationTime
 		if cs.operationTime != nil {
 			opTime = cs.sess.OperationTime
 		}
 
+		// Never resume before the time pinned by the StartAfterOperation option.
+		if pin := cs.options.StartAfterOperation; pin != nil &&
+			(opTime == nil || primitive.CompareTimestamp(*opTime, *pin) < 0) {
+			opTime = pin
+		}
+
 		cs.options.SetStartAtOperationTime(opTime)
 		cs.options.SetResumeAfter(nil)
 		cs.options.SetStartAfter(nil)
@@ -529,17 +1248,144 @@ func (cs *ChangeStream) ID() int64 {
 	return cs.cursor.ID()
 }
 
-// Decode will unmarshal the current event document into val and return any errors from the unmarshalling process
-// without any modification. If val is nil or is a typed nil, an error will be returned.
-func (cs *ChangeStream) Decode(val interface{}) error {
-	if cs.cursor == nil {
-		return ErrNilCursor
+// LastGetMoreUsedAfterClusterTime returns true if the causal consistency of the change stream's session added an
+// afterClusterTime field to the read concern of the aggregate that opened the current server cursor. getMore commands
+// never carry a read concern, so the getMores on a cursor read at the cluster time that its aggregate waited for. An
+// aggregate that carries afterClusterTime cannot complete until the selected server has caught up to that cluster
+// time, which can make the change stream stall on a lagging secondary when it is opened or resumed.
+func (cs *ChangeStream) LastGetMoreUsedAfterClusterTime() bool {
+	return cs.afterClusterTime
+}
+
+// Checkpoint returns the current position of the change stream, which can be passed to the StartFromCheckpoint option
+// to open a change stream that continues from the same position. The checkpoint includes the cached resume token,
+// whether it is a post-batch resume token, and the operation time that the change stream would resume from if it had
+// no resume token, so all of them describe the same position. The returned value does not share memory with the
+// change stream.
+func (cs *ChangeStream) Checkpoint() options.ChangeStreamCheckpoint {
+	var cp options.ChangeStreamCheckpoint
+	if cs.resumeToken != nil {
+		cp.ResumeToken = append(bson.Raw(nil), cs.resumeToken...)
+		cp.PostBatchResumeToken = cs.cursor != nil && bytes.Equal(cs.resumeToken, cs.cursor.PostBatchResumeToken())
 	}
 
-	return bson.UnmarshalWithRegistry(cs.registry, cs.Current, val)
+	// This matches the operation time chosen by replaceOptions when resuming without a resume token.
+	opTime := cs.options.StartAtOperationTime
+	if cs.operationTime != nil && cs.sess != nil && cs.sess.OperationTime != nil {
+		opTime = cs.sess.OperationTime
+	}
+	if opTime != nil {
+		t := *opTime
+		cp.OperationTime = &t
+	}
+	return cp
 }
 
-// Err returns the last error seen by the change stream, or nil if no errors has occurred.
+// InitialResumeMode returns the resume option that was sent in the aggregate command that opened the change stream.
+// The mode is one of "resumeAfter", "startAfter", "startAtOperationTime", or "none". For "resumeAfter" and
+// "startAfter", token is the resume token that was sent. For "startAtOperationTime", token is a document with a single
+// startAtOperationTime field containing the timestamp that was sent. For "none", token is nil. If the change stream was
+// opened at the FallbackStartAtOperationTime option because its resume token was rejected, the fallback aggregate is
+// reported. Resumes performed after the change stream was opened do not affect the returned values.
+func (cs *ChangeStream) InitialResumeMode() (mode string, token bson.Raw) {
+	return cs.initialResumeMode, cs.initialResumeToken
+}
+
+// ChangeStreamPosition describes the position of a change stream in the cluster. It is stored in a Context by
+// ChangeStream.ContextWithPosition and retrieved by ChangeStreamPositionFromContext.
+type ChangeStreamPosition struct {
+	// The resume token of the change stream when the position was recorded.
+	ResumeToken bson.Raw
+
+	// The clusterTime of the current event when the position was recorded. This is the zero Timestamp if the current
+	// event did not include a clusterTime.
+	ClusterTime primitive.Timestamp
+}
+
+type changeStreamPositionKey struct{}
+
+// ContextWithPosition returns a copy of ctx that stores the current position of the change stream, including the
+// resume token and the clusterTime of the current event. The position can be retrieved from the returned Context,
+// or any Context derived from it, using ChangeStreamPositionFromContext.
+//
+// To read data causally after the current event in a downstream call, start a causally consistent session on the
+// same Client, retrieve the position from the Context, and pass its ClusterTime to the session's
+// AdvanceOperationTime method before running the read.
+func (cs *ChangeStream) ContextWithPosition(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pos := ChangeStreamPosition{ResumeToken: cs.ResumeToken()}
+	if t, i, ok := cs.Current.Lookup("clusterTime").TimestampOK(); ok {
+		pos.ClusterTime = primitive.Timestamp{T: t, I: i}
+	}
+	return context.WithValue(ctx, changeStreamPositionKey{}, pos)
+}
+
+// ChangeStreamPositionFromContext returns the change stream position stored in ctx by ChangeStream.ContextWithPosition.
+// The second return value is false if ctx does not contain a position.
+func ChangeStreamPositionFromContext(ctx context.Context) (ChangeStreamPosition, bool) {
+	pos, ok := ctx.Value(changeStreamPositionKey{}).(ChangeStreamPosition)
+	return pos, ok
+}
+
+// recordServerAddress stores the address of the server selected by executeOperation. When resuming, it also records
+// whether the address differs from that of the server used previously.
+func (cs *ChangeStream) recordServerAddress(addr address.Address, resuming bool) {
+	if resuming {
+		cs.resumedToNewServer = cs.serverAddr != "" && addr != cs.serverAddr
+	}
+	cs.serverAddr = addr
+}
+
+// ResumedToNewServer returns true if the most recent resume of the change stream selected a different server than the
+// one the change stream was using before. This can be used to distinguish resumes caused by a failover from those
+// caused by transient errors on the same server. It returns false if the change stream has not resumed.
+func (cs *ChangeStream) ResumedToNewServer() bool {
+	return cs.resumedToNewServer
+}
+
+// SupportedFeatures returns the change stream features supported by the server that the change stream was most
+// recently opened or resumed on, based on its wire version. All features are reported as unsupported if the change
+// stream has not been opened.
+func (cs *ChangeStream) SupportedFeatures() ChangeStreamFeatures {
+	return changeStreamFeaturesFor(cs.wireVersion)
+}
+
+// WireVersion returns the wire version range of the server that the change stream was most recently opened or
+// resumed on, or nil if the change stream has not been opened. The returned value is updated each time the change
+// stream resumes and should not be modified.
+func (cs *ChangeStream) WireVersion() *description.VersionRange {
+	return cs.wireVersion
+}
+
+// Decode will unmarshal the current event document into val and return any errors from the unmarshalling process
+// without any modification. If val is nil or is a typed nil, an error will be returned.
+func (cs *ChangeStream) Decode(val interface{}) error {
+	if cs.cursor == nil {
+		return ErrNilCursor
+	}
+
+	return bson.UnmarshalWithRegistry(cs.registry, cs.Current, val)
+}
+
+// DecodeEvent unmarshals the current event document into ev. ev is reset before decoding, so fields that are not
+// present in the event are left as their zero values. Fields in the event that are not known to ChangeEvent are
+// ignored.
+func (cs *ChangeStream) DecodeEvent(ev *ChangeEvent) error {
+	if cs.cursor == nil {
+		return ErrNilCursor
+	}
+	if ev == nil {
+		return errors.New("DecodeEvent requires a non-nil ChangeEvent")
+	}
+
+	*ev = ChangeEvent{}
+	return bson.UnmarshalWithRegistry(cs.registry, cs.Current, ev)
+}
+
+// Err returns the last error seen by the change stream, or nil if no errors has occurred.
 func (cs *ChangeStream) Err() error {
 	if cs.err != nil {
 		return replaceErrors(cs.err)
@@ -560,6 +1406,8 @@ func (cs *ChangeStream) Close(ctx context.Context) error {
 
 	defer closeImplicitSession(cs.sess)
 
+	cs.closeDecodeErrors()
+
 	if cs.cursor == nil {
 		return nil // cursor is already closed
 	}
@@ -575,13 +1423,111 @@ func (cs *ChangeStream) ResumeToken() bson.Raw {
 	return cs.resumeToken
 }
 
+// TimeSinceLastEvent returns the time elapsed since Next or TryNext last returned an event. This can be used to tell
+// a change stream that is idle apart from one that is actively delivering events. Calls to Next or TryNext that do
+// not return an event, such as getMores that return an empty batch, do not reset the time. If no event has been
+// returned yet, TimeSinceLastEvent returns 0.
+func (cs *ChangeStream) TimeSinceLastEvent() time.Duration {
+	if cs.lastEventAt.IsZero() {
+		return 0
+	}
+	return cs.currentTime().Sub(cs.lastEventAt)
+}
+
+// CurrentResumeToken returns the resume token of the current event, which is the value of its _id field. Unlike
+// ResumeToken, this is never a post-batch resume token, so it can be passed to the ResumeAfter option to resume
+// immediately after the current event. The second return value is false if the current event does not have an _id
+// document.
+func (cs *ChangeStream) CurrentResumeToken() (bson.Raw, bool) {
+	token, ok := cs.Current.Lookup("_id").DocumentOK()
+	if !ok {
+		return nil, false
+	}
+	return token, true
+}
+
+// InSplitEvent returns true if the current event is a fragment of a large event that was split by the
+// $changeStreamSplitLargeEvent pipeline stage and the remaining fragments have not yet been returned by Next or
+// TryNext. Consumers should not act on the current event until InSplitEvent returns false. Because the resume token
+// of each fragment identifies that fragment, a change stream that is resumed between fragments will continue
+// returning the remaining fragments and InSplitEvent will remain true until the last one is returned.
+func (cs *ChangeStream) InSplitEvent() bool {
+	splitEvent, ok := cs.Current.Lookup("splitEvent").DocumentOK()
+	if !ok {
+		return false
+	}
+
+	fragment, ok := splitEvent.Lookup("fragment").AsInt32OK()
+	if !ok {
+		return false
+	}
+	of, ok := splitEvent.Lookup("of").AsInt32OK()
+	if !ok {
+		return false
+	}
+	return fragment < of
+}
+
+// CurrentCollectionUUID returns the UUID of the collection that the current event applies to. This is read from the
+// collectionUUID field of the event, which the server includes for some event types when ShowExpandedEvents is
+// enabled. The UUID can be used to distinguish between collections that were dropped and recreated with the same
+// name. The second return value is false if the current event does not include a collectionUUID field.
+func (cs *ChangeStream) CurrentCollectionUUID() (primitive.Binary, bool) {
+	subtype, data, ok := cs.Current.Lookup("collectionUUID").BinaryOK()
+	if !ok {
+		return primitive.Binary{}, false
+	}
+	return primitive.Binary{Subtype: subtype, Data: data}, true
+}
+
+// FindByDocumentKey returns a function that looks up a document in coll using the documentKey of a change event. It
+// is intended to be used with the FullDocumentViaFind change stream option. If no document matches, the function
+// returns nil without an error.
+func FindByDocumentKey(coll *Collection) func(context.Context, bson.Raw) (bson.Raw, error) {
+	return func(ctx context.Context, documentKey bson.Raw) (bson.Raw, error) {
+		doc, err := coll.FindOne(ctx, documentKey).DecodeBytes()
+		if err == ErrNoDocuments {
+			return nil, nil
+		}
+		return doc, err
+	}
+}
+
+// EncodeResumeToken encodes a resume token as a base64 string. This can be used to store a token returned by
+// ChangeStream.ResumeToken in systems that do not support binary data. The token can be restored using
+// DecodeResumeToken.
+func EncodeResumeToken(token bson.Raw) string {
+	return base64.StdEncoding.EncodeToString(token)
+}
+
+// DecodeResumeToken decodes a resume token that was encoded with EncodeResumeToken. The returned token can be
+// passed to the ResumeAfter or StartAfter change stream options. An error is returned if s is not valid base64
+// or does not contain a valid BSON document.
+func DecodeResumeToken(s string) (bson.Raw, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding resume token: %w", err)
+	}
+	token := bson.Raw(b)
+	if err := token.Validate(); err != nil {
+		return nil, fmt.Errorf("error decoding resume token: %w", err)
+	}
+	return token, nil
+}
+
 // Next gets the next event for this change stream. It returns true if there were no errors and the next event document
 // is available.
 //
-// Next blocks until an event is available, an error occurs, or ctx expires. If ctx expires, the error
-// will be set to ctx.Err(). In an error case, Next will return false.
+// Next never decodes the event into a Go value. The event is only available as raw BSON through Current until Decode
+// is called, so a caller that only reads Current does not pay for decoding.
+//
+// Next blocks until an event is available, an error occurs, or ctx expires. If ctx expires, Err will return an error
+// that matches ctx.Err() (context.Canceled or context.DeadlineExceeded) when checked with errors.Is. In an error case,
+// Next will return false.
 //
-// If Next returns false, subsequent calls will also return false.
+// If Next returns false, subsequent calls will also return false, unless Next returned false because ctx expired. In
+// that case, the error is cleared by the next call to Next or TryNext, which resumes the change stream from the last
+// resume token with its new context.
 func (cs *ChangeStream) Next(ctx context.Context) bool {
 	return cs.next(ctx, false)
 }
@@ -590,69 +1536,517 @@ func (cs *ChangeStream) Next(ctx context.Context) bool {
 // event document is available.
 //
 // TryNext returns false if the change stream is closed by the server, an error occurs when getting changes from the
-// server, the next change is not yet available, or ctx expires. If ctx expires, the error will be set to ctx.Err().
+// server, the next change is not yet available, or ctx expires. If ctx expires, Err will return an error that matches
+// ctx.Err() when checked with errors.Is.
 //
 // If TryNext returns false and an error occurred or the change stream was closed
 // (i.e. cs.Err() != nil || cs.ID() == 0), subsequent attempts will also return false. Otherwise, it is safe to call
-// TryNext again until a change is available.
+// TryNext again until a change is available. As with Next, an error caused by ctx expiring does not prevent
+// subsequent calls from continuing the change stream.
 //
 // This method requires driver version >= 1.2.0.
 func (cs *ChangeStream) TryNext(ctx context.Context) bool {
 	return cs.next(ctx, true)
 }
 
+// Advance skips the next n events for this change stream without decoding them. It returns true if n events were
+// available and false otherwise. Like Next, Advance blocks until each event is available, an error occurs, or ctx
+// expires. After Advance returns, Current and ResumeToken reflect the last event that was skipped, so the change
+// stream can be resumed after it. If n is 0 or negative, Advance returns true without changing the position of the
+// change stream.
+func (cs *ChangeStream) Advance(ctx context.Context, n int) bool {
+	for i := 0; i < n; i++ {
+		if !cs.next(ctx, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// NextDecode gets the next event for this change stream and decodes it into val. It returns true if an event was
+// retrieved and decoded successfully. See Next for a description of the blocking behavior.
+//
+// If the LenientDecode option is false or not set, a decode error stops the change stream and is returned by Err. If
+// LenientDecode is true, an event that cannot be decoded is skipped: its error is sent to the channel returned by
+// DecodeErrors as a ChangeStreamDecodeError, and NextDecode continues with the next event. A skipped event is not
+// returned again, so it is delivered at most once.
+func (cs *ChangeStream) NextDecode(ctx context.Context, val interface{}) bool {
+	for cs.Next(ctx) {
+		err := cs.Decode(val)
+		if err == nil {
+			return true
+		}
+
+		if cs.options.LenientDecode == nil || !*cs.options.LenientDecode {
+			cs.err = err
+			return false
+		}
+		decodeErr := ChangeStreamDecodeError{
+			ResumeToken: cs.ResumeToken(),
+			Event:       append(bson.Raw(nil), cs.Current...),
+			Err:         err,
+		}
+		cs.sendDecodeError(decodeErr)
+	}
+	return false
+}
+
+// DecodeErrors returns a channel that receives the errors for events skipped by NextDecode when the LenientDecode
+// option is true. The channel has a buffer of 64 errors. If the buffer is full when an event is skipped, its error
+// is discarded, so the channel should be drained concurrently or between calls to NextDecode. The channel is closed
+// when the change stream is closed.
+func (cs *ChangeStream) DecodeErrors() <-chan error {
+	return cs.decodeErrors
+}
+
+// sendDecodeError sends err to the DecodeErrors channel, discarding it if the buffer is full or the channel is closed.
+func (cs *ChangeStream) sendDecodeError(err ChangeStreamDecodeError) {
+	cs.decodeErrorsMu.Lock()
+	defer cs.decodeErrorsMu.Unlock()
+
+	if cs.decodeErrorsClosed {
+		return
+	}
+	select {
+	case cs.decodeErrors <- err:
+		if cs.decodeErrorSizes == nil {
+			cs.decodeErrorSizes = make([]int, cap(cs.decodeErrors))
+		}
+		cs.decodeErrorSizes[cs.decodeErrorsSent%len(cs.decodeErrorSizes)] = len(err.Event) + len(err.ResumeToken)
+		cs.decodeErrorsSent++
+	default:
+	}
+}
+
+// decodeErrorsFootprint returns the number of bytes of BSON data held by the errors buffered in the DecodeErrors
+// channel. The channel is a FIFO queue, so the buffered errors are the most recently sent ones.
+func (cs *ChangeStream) decodeErrorsFootprint() int {
+	cs.decodeErrorsMu.Lock()
+	defer cs.decodeErrorsMu.Unlock()
+
+	var size int
+	for i := 1; i <= len(cs.decodeErrors); i++ {
+		size += cs.decodeErrorSizes[(cs.decodeErrorsSent-i)%len(cs.decodeErrorSizes)]
+	}
+	return size
+}
+
+// closeDecodeErrors closes the DecodeErrors channel if it has not already been closed.
+func (cs *ChangeStream) closeDecodeErrors() {
+	cs.decodeErrorsMu.Lock()
+	defer cs.decodeErrorsMu.Unlock()
+
+	if cs.decodeErrors != nil && !cs.decodeErrorsClosed {
+		close(cs.decodeErrors)
+		cs.decodeErrorsClosed = true
+	}
+}
+
+// Pipe writes each event from this change stream to w in the given format until ctx expires, the change stream's
+// cursor is closed, or an error occurs. See Next for a description of the blocking behavior. After the last event, a
+// trailer document of the form {"resumeToken": <token>} is written if a resume token is available, so the output can
+// be used to resume the change stream with the ResumeAfter option. The trailer can be told apart from events because
+// it has no _id field.
+//
+// Pipe returns the first error encountered while writing to w or iterating the change stream, or nil if the change
+// stream ended without error.
+func (cs *ChangeStream) Pipe(ctx context.Context, w io.Writer, format StreamFormat) error {
+	switch format {
+	case FormatNDJSON, FormatBSON, FormatCanonicalJSON:
+	default:
+		return fmt.Errorf("invalid stream format: %v", format)
+	}
+
+	for cs.Next(ctx) {
+		if err := writeStreamRecord(w, cs.Current, format); err != nil {
+			return err
+		}
+	}
+
+	if cs.resumeToken != nil {
+		trailer, err := bson.Marshal(bson.D{{"resumeToken", cs.resumeToken}})
+		if err != nil {
+			return err
+		}
+		if err := writeStreamRecord(w, trailer, format); err != nil {
+			return err
+		}
+	}
+	return cs.Err()
+}
+
+// writeStreamRecord writes doc to w in the given format. JSON formats write one document per line.
+func writeStreamRecord(w io.Writer, doc bson.Raw, format StreamFormat) error {
+	if format == FormatBSON {
+		_, err := w.Write(doc)
+		return err
+	}
+
+	b, err := bson.MarshalExtJSON(doc, format == FormatCanonicalJSON, false)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// ParallelEach calls fn for each event in the change stream using a pool of the given number of worker goroutines,
+// until the change stream is closed, ctx expires, or fn returns an error. Each event passed to fn is a copy that can
+// be retained after fn returns. Events may be processed out of order, but CommittedResumeToken only advances to the
+// resume token of an event once fn has returned successfully for it and for every event before it, so resuming after
+// that token will never skip an unprocessed event.
+//
+// If fn returns an error, no more events are dispatched, ParallelEach waits for the events already being processed
+// to finish, and the first error is returned. The change stream itself may be left with a context error in this case,
+// which is cleared by the next call to Next or TryNext. Otherwise, ParallelEach returns the error reported by Err.
+func (cs *ChangeStream) ParallelEach(ctx context.Context, workers int, fn func(bson.Raw) error) error {
+	if workers < 1 {
+		return fmt.Errorf("workers must be at least 1, got %d", workers)
+	}
+
+	type job struct {
+		seq   uint64
+		event bson.Raw
+	}
+	type result struct {
+		seq uint64
+		err error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan job)
+	results := make(chan result, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				err := fn(j.event)
+				if err != nil {
+					// Stop dispatching right away, even if the dispatcher is blocked waiting for the next event.
+					cancel()
+				}
+				results <- result{seq: j.seq, err: err}
+			}
+		}()
+	}
+
+	// tokens holds the resume tokens of dispatched events that have not been committed, keyed by sequence number.
+	// Events that have finished out of order are recorded in finished until every event before them has finished.
+	tokens := make(map[uint64]bson.Raw)
+	finished := make(map[uint64]bool)
+	var lowest, seq uint64
+	var fnErr error
+	handle := func(r result) {
+		if r.err != nil {
+			if fnErr == nil {
+				fnErr = r.err
+			}
+			return
+		}
+		finished[r.seq] = true
+		for finished[lowest] {
+			cs.committedMu.Lock()
+			cs.committedToken = tokens[lowest]
+			cs.committedMu.Unlock()
+			delete(finished, lowest)
+			delete(tokens, lowest)
+			lowest++
+		}
+	}
+
+dispatch:
+	for fnErr == nil && cs.Next(ctx) {
+		token, _ := cs.CurrentResumeToken()
+		tokens[seq] = token
+		j := job{seq: seq, event: append(bson.Raw(nil), cs.Current...)}
+		seq++
+
+		for {
+			select {
+			case jobs <- j:
+				continue dispatch
+			case r := <-results:
+				handle(r)
+				if fnErr != nil {
+					break dispatch
+				}
+			}
+		}
+	}
+
+	close(jobs)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	for r := range results {
+		handle(r)
+	}
+
+	if fnErr != nil {
+		return fnErr
+	}
+	return cs.Err()
+}
+
+// CommittedResumeToken returns the resume token of the latest event that has been processed by ParallelEach along with
+// every event before it, or nil if ParallelEach has not finished processing any events. CommittedResumeToken is safe
+// to call from the function passed to ParallelEach.
+func (cs *ChangeStream) CommittedResumeToken() bson.Raw {
+	cs.committedMu.Lock()
+	defer cs.committedMu.Unlock()
+	return cs.committedToken
+}
+
 func (cs *ChangeStream) next(ctx context.Context, nonBlocking bool) bool {
-	// return false right away if the change stream has already errored or if cursor is closed.
+	// return false right away if the change stream has already errored or if cursor is closed. An error caused by a
+	// previous context expiring is cleared and the change stream is resumed so it can continue.
+	var resume bool
 	if cs.err != nil {
-		return false
+		if !cs.contextErr || cs.cursor == nil {
+			return false
+		}
+		cs.err = nil
+		cs.contextErr = false
+		resume = true
 	}
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	cs.getMoreInterrupted = false
 
-	if len(cs.batch) == 0 {
-		cs.loopNext(ctx, nonBlocking)
-		if cs.err != nil {
-			cs.err = replaceErrors(cs.err)
+	if resume {
+		// The server may have run the getMore that was cancelled, so the cursor cannot be trusted to continue from the
+		// last event returned. Resume from the cached resume token instead.
+		_ = cs.cursor.Close(ctx)
+		if cs.err = cs.executeOperation(ctx, true); cs.err != nil {
+			cs.recordNextError(ctx)
 			return false
 		}
+	}
+
+	cs.canUnread = false
+	for {
 		if len(cs.batch) == 0 {
+			cs.loopNext(ctx, nonBlocking)
+			if cs.err != nil {
+				cs.recordNextError(ctx)
+				return false
+			}
+			if len(cs.batch) == 0 {
+				return false
+			}
+		}
+
+		if !cs.collectSplitEvent() {
+			// The next event is a fragment of a split event whose remaining fragments are in a later batch.
+			continue
+		}
+		if !cs.missingPreImage(bson.Raw(cs.batch[0])) && cs.sampleEvent() {
+			break
+		}
+		// Skip the event but advance the resume token past it so it is not returned after a resume.
+		cs.Current = bson.Raw(cs.batch[0])
+		cs.batch = cs.batch[1:]
+		cs.err = cs.storeResumeToken()
+		cs.Current = nil
+		if cs.err != nil && cs.canRestart() {
+			cs.err = cs.restart(ctx)
+		}
+		if cs.err != nil {
 			return false
 		}
 	}
 
 	// successfully got non-empty batch
+	if cs.err = cs.lookupFullDocument(ctx); cs.err != nil {
+		return false
+	}
+	if cs.err = cs.checkBackfill(bson.Raw(cs.batch[0])); cs.err != nil {
+		return false
+	}
+	if cs.err = cs.checkFullDocument(bson.Raw(cs.batch[0])); cs.err != nil {
+		return false
+	}
+	if cs.err = cs.checkClusterTime(bson.Raw(cs.batch[0])); cs.err != nil {
+		return false
+	}
 	cs.Current = bson.Raw(cs.batch[0])
 	cs.batch = cs.batch[1:]
-	if cs.err = cs.storeResumeToken(); cs.err != nil {
+	cs.prevResumeToken = cs.resumeToken
+	if cs.err = cs.storeResumeToken(); cs.err != nil && cs.canRestart() {
+		// The event is still returned, but the change stream cannot be resumed after it, so it is restarted now.
+		cs.err = cs.restart(ctx)
+	}
+	if cs.err != nil {
 		return false
 	}
+	cs.canUnread = true
+	cs.lastEventAt = cs.currentTime()
+	cs.sequence++
 	return true
 }
 
+// Interrupt stops a call to Next or TryNext in another goroutine that is blocked waiting for the server to return more
+// events. The interrupted call returns false and Err returns an error that matches ErrInterrupted. The change stream
+// is not closed, and the error is cleared by the next call to Next or TryNext, which resumes the change stream after
+// the last event that was returned. If no call to Next or TryNext is waiting for the server, Interrupt has no effect.
+//
+// Interrupt is safe to call concurrently with Next and TryNext. No other ChangeStream methods are safe for concurrent
+// use.
+func (cs *ChangeStream) Interrupt() {
+	cs.interruptMu.Lock()
+	defer cs.interruptMu.Unlock()
+
+	if cs.interruptCancel != nil {
+		cs.interrupted = true
+		cs.interruptCancel()
+	}
+}
+
+// interruptibleContext returns a context derived from ctx that is cancelled when Interrupt is called.
+func (cs *ChangeStream) interruptibleContext(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	cs.interruptMu.Lock()
+	defer cs.interruptMu.Unlock()
+	cs.interruptCancel = cancel
+	cs.interrupted = false
+	return ctx
+}
+
+// clearInterrupt releases the context returned by interruptibleContext and records whether Interrupt was called.
+func (cs *ChangeStream) clearInterrupt() {
+	cs.interruptMu.Lock()
+	defer cs.interruptMu.Unlock()
+
+	cs.getMoreInterrupted = cs.interrupted
+
+	if cs.interruptCancel != nil {
+		cs.interruptCancel()
+		cs.interruptCancel = nil
+	}
+}
+
+// recordNextError records whether cs.err was caused by the ctx passed to Next or TryNext expiring, in which case the
+// next call resumes the change stream, and converts cs.err to the error returned by Err.
+func (cs *ChangeStream) recordNextError(ctx context.Context) {
+	if cs.isContextError(ctx) {
+		cs.contextErr = true
+		if cs.getMoreInterrupted {
+			cs.err = wrappedSentinelError{sentinel: ErrInterrupted, err: cs.err}
+		}
+	}
+	cs.err = cs.classifyPreImageError(replaceErrors(cs.err))
+}
+
+// isContextError returns true if cs.err was caused by ctx expiring or by Interrupt cancelling the getMore.
+func (cs *ChangeStream) isContextError(ctx context.Context) bool {
+	if cs.getMoreInterrupted {
+		return errors.Is(cs.err, context.Canceled)
+	}
+	ctxErr := ctx.Err()
+	return ctxErr != nil && errors.Is(cs.err, ctxErr)
+}
+
+// Unread pushes the current event back onto the change stream so that it will be returned again by the next call to
+// Next or TryNext. The resume token is reverted to its value before the current event was returned. Only a single
+// event can be unread: Unread returns false if it has already been called since the last successful call to Next or
+// TryNext, or if there is no current event. After a successful call, Current is nil until Next or TryNext is called.
+func (cs *ChangeStream) Unread() bool {
+	if !cs.canUnread {
+		return false
+	}
+
+	cs.batch = append([]bsoncore.Document{bsoncore.Document(cs.Current)}, cs.batch...)
+	cs.resumeToken = cs.prevResumeToken
+	cs.prevResumeToken = nil
+	cs.Current = nil
+	cs.canUnread = false
+	cs.sequence--
+	return true
+}
+
+// LastErrorLabels returns the error labels of the most recent error returned by the server or the network while
+// opening, resuming, or iterating the change stream, such as "ResumableChangeStreamError",
+// "NonResumableChangeStreamError", or "NetworkError". These are the labels that the change stream uses to decide
+// whether to resume. The labels are kept after the change stream resumes successfully, so they can be inspected
+// even though the error was not returned to the caller. LastErrorLabels returns an empty slice if no error has
+// occurred or the most recent error is not a CommandError. The returned slice does not share memory with the change
+// stream.
+func (cs *ChangeStream) LastErrorLabels() []string {
+	return append([]string{}, cs.lastErrorLabels...)
+}
+
+// CurrentSequence returns the sequence number of the current event. The first event returned by Next or TryNext has
+// sequence number 1, and each following event has a sequence number one greater than the event before it, including
+// across resumes. Sequence numbers are local to the ChangeStream and start again at 1 for a new ChangeStream, even if it
+// is resumed from the same position. An event pushed back by Unread keeps its sequence number when it is returned
+// again. CurrentSequence returns 0 if no event has been returned.
+func (cs *ChangeStream) CurrentSequence() uint64 {
+	return cs.sequence
+}
+
+// MemoryFootprint returns the approximate number of bytes of BSON data held by the change stream: the current event,
+// the events remaining in the current batch, the fragments of a split event that is being reassembled, the cached
+// resume tokens, and the events and resume tokens of the errors waiting in the DecodeErrors channel. It only adds up
+// the lengths of these documents, so it is cheap enough to call after every event. A document that shares memory with
+// another, such as a resume token taken from the current event, is counted each time it is held, and the unused part
+// of the buffer that a batch was read into is not counted, so the result is an estimate rather than an exact
+// measurement. Memory held by the connection pool or by the cursor on the server is not included.
+func (cs *ChangeStream) MemoryFootprint() int {
+	size := len(cs.Current) + len(cs.resumeToken) + len(cs.prevResumeToken) + len(cs.CommittedResumeToken()) +
+		len(cs.initialResumeToken) + cs.decodeErrorsFootprint()
+	for _, doc := range cs.batch {
+		size += len(doc)
+	}
+	for _, fragment := range cs.splitFragments {
+		size += len(fragment)
+	}
+	return size
+}
+
 func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
+	// The number of times the change stream has been restarted during this call. It is bounded so that a stream whose
+	// history is lost again right after each restart does not loop forever.
+	var restarts int
+	// The number of times a failed getMore has been retried on the current cursor during this call.
+	var getMoreRetries int
 	for {
 		if cs.cursor == nil {
 			return
 		}
 
-		if cs.cursor.Next(ctx) {
+		if cs.cursorNext(ctx) {
 			// non-empty batch returned
 			cs.batch, cs.err = cs.cursor.Batch().Documents()
+			cs.captureRawEvents()
 			return
 		}
 
 		cs.err = replaceErrors(cs.cursor.Err())
+		cs.recordErrorLabels()
 		if cs.err == nil {
 			// Check if cursor is alive
 			if cs.ID() == 0 {
-				return
+				cs.notifyCursorClosed()
+				recreated, err := cs.recreate(ctx)
+				if cs.err = err; !recreated || err != nil {
+					return
+				}
+				continue
 			}
 
 			// If a getMore was done but the batch was empty, the batch cursor will return false with no error.
 			// Update the tracked resume token to catch the post batch resume token from the server response.
 			cs.updatePbrtFromCommand()
+			// An empty batch means there are no more events waiting to be returned.
+			cs.backfillDone = true
 			if nonBlocking {
 				// stop after a successful getMore, even though the batch was empty
 				return
@@ -660,18 +2054,133 @@ func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 			continue // loop getMore until a non-empty batch is returned or an error occurs
 		}
 
-		if !cs.isResumableError() {
+		// An error caused by ctx expiring is not resumed because resuming would fail with the same context. The change
+		// stream is resumed by the next call instead.
+		if cs.isContextError(ctx) {
 			return
 		}
 
+		if cs.canRestart() && restarts < maxRestartsPerNext {
+			restarts++
+			if cs.err = cs.restart(ctx); cs.err != nil {
+				return
+			}
+			continue
+		}
+		if cs.isTerminalError() || !cs.isResumableError() {
+			return
+		}
+		if cs.canRetryGetMore(getMoreRetries) {
+			// The cursor is still open, so the next iteration sends another getMore with the same cursor ID. The resume
+			// token is only advanced by events that are returned, so it is not affected by the failed attempt.
+			cs.waitGetMoreRetry(ctx, getMoreRetries)
+			getMoreRetries++
+			continue
+		}
+
 		// ignore error from cursor close because if the cursor is deleted or errors we tried to close it and will remake and try to get next batch
 		_ = cs.cursor.Close(ctx)
-		if cs.err = cs.executeOperation(ctx, true); cs.err != nil {
+		getMoreRetries = 0
+		cs.err = cs.executeOperation(ctx, true)
+		if cs.err != nil && cs.canRestart() && restarts < maxRestartsPerNext {
+			restarts++
+			cs.err = cs.restart(ctx)
+		}
+		if cs.err != nil {
 			return
 		}
 	}
 }
 
+// cursorNext calls Next on the underlying cursor, reporting how long the call took to the GetMoreLatencyHook option if
+// it is set.
+func (cs *ChangeStream) cursorNext(ctx context.Context) bool {
+	ctx = cs.interruptibleContext(ctx)
+	defer cs.clearInterrupt()
+
+	if cs.options.GetMoreLatencyHook == nil {
+		return cs.cursor.Next(ctx)
+	}
+
+	start := cs.currentTime()
+	ok := cs.cursor.Next(ctx)
+	var docs int
+	if ok {
+		docs = cs.cursor.Batch().DocumentCount()
+	}
+	cs.options.GetMoreLatencyHook(cs.currentTime().Sub(start), docs)
+	return ok
+}
+
+// captureRawEvents passes a copy of each document in the current batch to the RawEventCapture option if it is set. The
+// documents must be copied because they share the cursor's buffer, which is reused for the next batch.
+func (cs *ChangeStream) captureRawEvents() {
+	if cs.options.RawEventCapture == nil {
+		return
+	}
+	for _, doc := range cs.batch {
+		cs.options.RawEventCapture(append([]byte(nil), doc...))
+	}
+}
+
+// canRetryGetMore returns true if the GetMoreRetries option allows the getMore that failed with the current error to be
+// retried on the existing cursor. Only network errors are retried, because a server error means the server has
+// already rejected the getMore for this cursor.
+func (cs *ChangeStream) canRetryGetMore(retries int) bool {
+	if cs.options.GetMoreRetries == nil || retries >= *cs.options.GetMoreRetries {
+		return false
+	}
+
+	commandErr, ok := cs.err.(CommandError)
+	return ok && commandErr.HasErrorLabel(networkErrorLabel)
+}
+
+// waitGetMoreRetry waits before the getMore retry after the given number of previous retries. It returns early if ctx
+// expires, in which case the retried getMore fails with the context error.
+func (cs *ChangeStream) waitGetMoreRetry(ctx context.Context, retries int) {
+	timer := time.NewTimer(getMoreRetryBackoff << uint(retries))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// notifyCursorClosed calls the user-provided OnCursorClosed function if it has not already been called for the current
+// cursor.
+func (cs *ChangeStream) notifyCursorClosed() {
+	if cs.options.OnCursorClosed == nil || cs.cursorClosedNotified {
+		return
+	}
+	cs.cursorClosedNotified = true
+	cs.options.OnCursorClosed()
+}
+
+// isTerminalError returns true if the user-provided TerminalErrorPredicate reports that the current error must not be
+// resumed.
+func (cs *ChangeStream) isTerminalError() bool {
+	if cs.options.TerminalErrorPredicate == nil {
+		return false
+	}
+
+	commandErr, ok := cs.err.(CommandError)
+	return ok && cs.options.TerminalErrorPredicate(commandErr)
+}
+
+// recordErrorLabels records the labels of cs.err for LastErrorLabels. The recorded labels are cleared if cs.err is not a
+// CommandError and kept if cs.err is nil, so they still describe the most recent error after a successful resume.
+func (cs *ChangeStream) recordErrorLabels() {
+	if cs.err == nil {
+		return
+	}
+
+	cs.lastErrorLabels = nil
+	if commandErr, ok := cs.err.(CommandError); ok && len(commandErr.Labels) > 0 {
+		cs.lastErrorLabels = append([]string(nil), commandErr.Labels...)
+	}
+}
+
 func (cs *ChangeStream) isResumableError() bool {
 	commandErr, ok := cs.err.(CommandError)
 	if !ok || commandErr.HasErrorLabel(networkErrorLabel) {
@@ -684,7 +2193,7 @@ func (cs *ChangeStream) isResumableError() bool {
 	}
 
 	// For wire versions 9 and above, a server error is resumable if it has the ResumableChangeStreamError label.
-	if cs.wireVersion != nil && cs.wireVersion.Includes(minResumableLabelWireVersion) {
+	if changeStreamFeaturesFor(cs.wireVersion).ResumableErrorLabel {
 		return commandErr.HasErrorLabel(resumableErrorLabel)
 	}
 
@@ -693,6 +2202,222 @@ func (cs *ChangeStream) isResumableError() bool {
 	return resumable
 }
 
+// currentTime returns the current time according to the change stream's clock.
+func (cs *ChangeStream) currentTime() time.Time {
+	if cs.now != nil {
+		return cs.now()
+	}
+	return time.Now()
+}
+
+// checkBackfill returns ErrBackfillTooSlow if the MaxBackfillDuration option is set and the given event is still
+// older than the time the change stream was opened after the backfill window has elapsed.
+func (cs *ChangeStream) checkBackfill(event bson.Raw) error {
+	if cs.options.MaxBackfillDuration == nil || cs.backfillDone {
+		return nil
+	}
+
+	now := cs.currentTime()
+	if cs.backfillDeadline.IsZero() {
+		cs.backfillDeadline = now.Add(*cs.options.MaxBackfillDuration)
+	}
+
+	t, _, ok := event.Lookup("clusterTime").TimestampOK()
+	if !ok || !time.Unix(int64(t), 0).Before(cs.openedAt.Truncate(time.Second)) {
+		cs.backfillDone = true
+		return nil
+	}
+	if now.After(cs.backfillDeadline) {
+		return ErrBackfillTooSlow
+	}
+	return nil
+}
+
+// lookupFullDocument uses the user-provided FullDocumentViaFind function to add a fullDocument field to the next event
+// in the batch if it is an update event without one.
+func (cs *ChangeStream) lookupFullDocument(ctx context.Context) error {
+	if cs.options.FullDocumentViaFind == nil {
+		return nil
+	}
+
+	event := bson.Raw(cs.batch[0])
+	if opType, _ := event.Lookup("operationType").StringValueOK(); opType != "update" {
+		return nil
+	}
+	if _, err := event.LookupErr("fullDocument"); err == nil {
+		return nil
+	}
+	documentKey, ok := event.Lookup("documentKey").DocumentOK()
+	if !ok {
+		return nil
+	}
+
+	fullDoc, err := cs.options.FullDocumentViaFind(ctx, documentKey)
+	if err != nil {
+		return err
+	}
+
+	elems, err := bsoncore.Document(event).Elements()
+	if err != nil {
+		return err
+	}
+	idx, doc := bsoncore.AppendDocumentStart(make([]byte, 0, len(event)+len(fullDoc)+len("fullDocument")+2))
+	for _, elem := range elems {
+		doc = append(doc, elem...)
+	}
+	if fullDoc == nil {
+		doc = bsoncore.AppendNullElement(doc, "fullDocument")
+	} else {
+		doc = bsoncore.AppendDocumentElement(doc, "fullDocument", fullDoc)
+	}
+	if doc, err = bsoncore.AppendDocumentEnd(doc, idx); err != nil {
+		return err
+	}
+	cs.batch[0] = doc
+	return nil
+}
+
+// collectSplitEvent reassembles split events when the SplitLargeChanges option is set. If the next event in the batch
+// is a fragment of a split event, it is removed from the batch and collected. Once the last fragment has been
+// collected, the reassembled event is put back at the front of the batch. collectSplitEvent returns false if the
+// fragment it collected was not the last one, in which case the batch does not contain an event ready to be returned.
+func (cs *ChangeStream) collectSplitEvent() bool {
+	if cs.options.SplitLargeChanges == nil || !*cs.options.SplitLargeChanges {
+		return true
+	}
+
+	splitEvent, ok := bson.Raw(cs.batch[0]).Lookup("splitEvent").DocumentOK()
+	if !ok {
+		return true
+	}
+	fragment, _ := splitEvent.Lookup("fragment").AsInt32OK()
+	of, _ := splitEvent.Lookup("of").AsInt32OK()
+
+	// The first fragment is returned again if the change stream resumes before the event is complete, so start over.
+	if fragment == 1 {
+		cs.splitFragments = cs.splitFragments[:0]
+	}
+	cs.splitFragments = append(cs.splitFragments, cs.batch[0])
+	cs.batch = cs.batch[1:]
+	if fragment < of {
+		return false
+	}
+
+	cs.batch = append([]bsoncore.Document{mergeSplitEvent(cs.splitFragments)}, cs.batch...)
+	cs.splitFragments = nil
+	return true
+}
+
+// mergeSplitEvent combines the fields of the given fragments into a single event. The splitEvent field is removed
+// and the _id of the last fragment is used as the resume token of the combined event.
+func mergeSplitEvent(fragments []bsoncore.Document) bsoncore.Document {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	last := fragments[len(fragments)-1]
+	if id, err := last.LookupErr("_id"); err == nil {
+		doc = bsoncore.AppendValueElement(doc, "_id", id)
+	}
+	for _, fragment := range fragments {
+		elems, _ := fragment.Elements()
+		for _, elem := range elems {
+			switch elem.Key() {
+			case "_id", "splitEvent":
+				continue
+			}
+			doc = append(doc, elem...)
+		}
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
+// skipMissingPreImage returns true if events without a pre-image should be skipped because both the
+// SkipMissingPreImage option is true and FullDocumentBeforeChange is options.Required.
+func (cs *ChangeStream) skipMissingPreImage() bool {
+	return cs.options.SkipMissingPreImage != nil && *cs.options.SkipMissingPreImage &&
+		cs.options.FullDocumentBeforeChange != nil && *cs.options.FullDocumentBeforeChange == options.Required
+}
+
+// missingPreImage returns true if event should be skipped because it does not have the pre-image requested by the
+// FullDocumentBeforeChange option.
+func (cs *ChangeStream) missingPreImage(event bson.Raw) bool {
+	if !cs.skipMissingPreImage() {
+		return false
+	}
+	switch opType, _ := event.Lookup("operationType").StringValueOK(); opType {
+	case "update", "replace", "delete":
+	default:
+		return false
+	}
+	preImage, err := event.LookupErr("fullDocumentBeforeChange")
+	return err != nil || preImage.Type == bson.TypeNull
+}
+
+// classifyPreImageError wraps err so that it matches ErrPreImageUnavailable if it is the error the server returns when
+// FullDocumentBeforeChange is options.Required and an event's pre-image cannot be found.
+func (cs *ChangeStream) classifyPreImageError(err error) error {
+	if cs.options.FullDocumentBeforeChange == nil || *cs.options.FullDocumentBeforeChange != options.Required {
+		return err
+	}
+	commandErr, ok := err.(CommandError)
+	if !ok || commandErr.Code != errorNoMatchingDocument {
+		return err
+	}
+	return wrappedSentinelError{sentinel: ErrPreImageUnavailable, err: commandErr}
+}
+
+// sampleEvent reports whether the next event should be returned according to the SampleRate option.
+func (cs *ChangeStream) sampleEvent() bool {
+	if cs.options.SampleRate == nil {
+		return true
+	}
+	if cs.sampler == nil {
+		seed := time.Now().UnixNano()
+		if cs.options.SampleSeed != nil {
+			seed = *cs.options.SampleSeed
+		}
+		cs.sampler = rand.New(rand.NewSource(seed))
+	}
+	return cs.sampler.Float64() < *cs.options.SampleRate
+}
+
+// checkFullDocument returns ErrMissingFullDocument if event is an update event without a fullDocument field and the
+// change stream was configured to disallow missing documents for UpdateLookup.
+func (cs *ChangeStream) checkFullDocument(event bson.Raw) error {
+	if cs.options.AllowMissingDocuments == nil || *cs.options.AllowMissingDocuments {
+		return nil
+	}
+	if cs.options.FullDocument == nil || *cs.options.FullDocument != options.UpdateLookup {
+		return nil
+	}
+	if opType, _ := event.Lookup("operationType").StringValueOK(); opType != "update" {
+		return nil
+	}
+	// The server returns a null fullDocument if the document was deleted before it could be looked up.
+	if fullDoc, err := event.LookupErr("fullDocument"); err != nil || fullDoc.Type == bson.TypeNull {
+		return ErrMissingFullDocument
+	}
+	return nil
+}
+
+// checkClusterTime returns ErrClusterTimeRegression if DetectClusterTimeRegression is enabled and the clusterTime of
+// event is earlier than the clusterTime of the previous event. Events without a clusterTime are not checked.
+func (cs *ChangeStream) checkClusterTime(event bson.Raw) error {
+	if cs.options.DetectClusterTimeRegression == nil || !*cs.options.DetectClusterTimeRegression {
+		return nil
+	}
+
+	t, i, ok := event.Lookup("clusterTime").TimestampOK()
+	if !ok {
+		return nil
+	}
+	clusterTime := primitive.Timestamp{T: t, I: i}
+	if primitive.CompareTimestamp(clusterTime, cs.lastClusterTime) < 0 {
+		return fmt.Errorf("%w: got %v after %v", ErrClusterTimeRegression, clusterTime, cs.lastClusterTime)
+	}
+	cs.lastClusterTime = clusterTime
+	return nil
+}
+
 // Returns true if the underlying cursor's batch is empty
 func (cs *ChangeStream) emptyBatch() bool {
 	return cs.cursor.Batch().Empty()
@@ -708,3 +2433,120 @@ const (
 	DatabaseStream
 	ClientStream
 )
+
+// StreamFormat specifies the format in which ChangeStream.Pipe writes events.
+type StreamFormat uint8
+
+// These constants represent the formats supported by ChangeStream.Pipe.
+const (
+	// FormatNDJSON writes each event as relaxed Extended JSON on its own line.
+	FormatNDJSON StreamFormat = iota
+	// FormatBSON writes each event as a BSON document, with no separator between documents.
+	FormatBSON
+	// FormatCanonicalJSON writes each event as canonical Extended JSON on its own line.
+	FormatCanonicalJSON
+)
+
+// ChangeEvent is a typed representation of a change stream event that can be populated using
+// ChangeStream.DecodeEvent. Fields that are not included in an event are left as their zero values.
+type ChangeEvent struct {
+	// The resume token for the event.
+	ID bson.Raw `bson:"_id"`
+
+	// The type of operation that caused the event (e.g. "insert", "update", or "invalidate").
+	OperationType string `bson:"operationType"`
+
+	// The timestamp of the oplog entry associated with the event.
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+
+	// The server date and time at which the event occurred. This is only included by MongoDB versions >= 6.0.
+	WallTime time.Time `bson:"wallTime"`
+
+	// The namespace affected by the event.
+	Ns ChangeEventNamespace `bson:"ns"`
+
+	// The new namespace for rename events.
+	To *ChangeEventNamespace `bson:"to"`
+
+	// The _id, and shard key if the collection is sharded, of the document affected by the event.
+	DocumentKey bson.Raw `bson:"documentKey"`
+
+	// The document after the change. This is included for insert and replace events, and for update events if the
+	// FullDocument option is set.
+	FullDocument bson.Raw `bson:"fullDocument"`
+
+	// The document before the change. This is only included if the FullDocumentBeforeChange option is set.
+	FullDocumentBeforeChange bson.Raw `bson:"fullDocumentBeforeChange"`
+
+	// A description of the fields changed by an update event.
+	UpdateDescription *ChangeEventUpdateDescription `bson:"updateDescription"`
+
+	// The transaction number and session ID of the transaction that the event was part of, if any.
+	TxnNumber *int64   `bson:"txnNumber"`
+	LSID      bson.Raw `bson:"lsid"`
+}
+
+// ChangeEventNamespace is the namespace of a ChangeEvent.
+type ChangeEventNamespace struct {
+	DB   string `bson:"db"`
+	Coll string `bson:"coll"`
+}
+
+// ChangeEventUpdateDescription describes the fields changed by an update event.
+type ChangeEventUpdateDescription struct {
+	// The fields that were set or changed and their new values.
+	UpdatedFields bson.Raw `bson:"updatedFields"`
+
+	// The names of the fields that were removed.
+	RemovedFields []string `bson:"removedFields"`
+
+	// The arrays that were truncated.
+	TruncatedArrays []ChangeEventTruncatedArray `bson:"truncatedArrays"`
+}
+
+// ChangeEventTruncatedArray describes an array that was truncated by an update event.
+type ChangeEventTruncatedArray struct {
+	Field   string `bson:"field"`
+	NewSize int32  `bson:"newSize"`
+}
+
+// ChangeStreamFeatures reports which change stream features are supported by a server. It is returned by
+// ChangeStream.SupportedFeatures.
+type ChangeStreamFeatures struct {
+	// The StartAtOperationTime option is supported and change streams can be resumed from an operation time. This
+	// requires MongoDB 4.0.
+	StartAtOperationTime bool
+
+	// The StartAfter option is supported. This requires MongoDB 4.2.
+	StartAfter bool
+
+	// The server adds the ResumableChangeStreamError label to errors that a change stream can resume from, instead of
+	// the driver using a fixed list of error codes. This requires MongoDB 4.4.
+	ResumableErrorLabel bool
+
+	// The FullDocumentBeforeChange option and the WhenAvailable and Required modes of the FullDocument option are
+	// supported. This requires MongoDB 6.0.
+	PreImages bool
+
+	// The ShowExpandedEvents option is supported. This requires MongoDB 6.0.
+	ExpandedEvents bool
+
+	// The SplitLargeChanges option is supported. This requires MongoDB 7.0.
+	SplitLargeEvents bool
+}
+
+// changeStreamFeaturesFor returns the change stream features supported by a server with the given wire version range.
+func changeStreamFeaturesFor(wireVersion *description.VersionRange) ChangeStreamFeatures {
+	if wireVersion == nil {
+		return ChangeStreamFeatures{}
+	}
+
+	return ChangeStreamFeatures{
+		StartAtOperationTime: wireVersion.Max >= minStartAtOperationTimeWireVersion,
+		StartAfter:           wireVersion.Max >= minStartAfterWireVersion,
+		ResumableErrorLabel:  wireVersion.Max >= minResumableLabelWireVersion,
+		PreImages:            wireVersion.Max >= minPreImagesWireVersion,
+		ExpandedEvents:       wireVersion.Max >= minExpandedEventsWireVersion,
+		SplitLargeEvents:     wireVersion.Max >= minSplitLargeEventsWireVersion,
+	}
+}
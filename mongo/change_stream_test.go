@@ -7,11 +7,248 @@
 package mongo
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
+type testChangeStreamCursor struct {
+	batches []*bsoncore.DocumentSequence
+	batch   *bsoncore.DocumentSequence
+	pbrt    bsoncore.Document
+	err     error
+	ctxErr  error
+	closed  bool
+}
+
+var _ changeStreamCursor = (*testChangeStreamCursor)(nil)
+
+// newTestChangeStreamCursor creates a cursor that returns one batch per element of batches.
+func newTestChangeStreamCursor(t *testing.T, batches ...[]bson.D) *testChangeStreamCursor {
+	t.Helper()
+
+	tcsc := &testChangeStreamCursor{}
+	for _, batch := range batches {
+		var docSequence []byte
+		for _, doc := range batch {
+			b, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			docSequence = append(docSequence, b...)
+		}
+		tcsc.batches = append(tcsc.batches, &bsoncore.DocumentSequence{
+			Style: bsoncore.SequenceStyle,
+			Data:  docSequence,
+		})
+	}
+	return tcsc
+}
+
+func (tcsc *testChangeStreamCursor) ID() int64 {
+	if tcsc.closed || len(tcsc.batches) == 0 {
+		return 0
+	}
+	return 10
+}
+
+func (tcsc *testChangeStreamCursor) Next(ctx context.Context) bool {
+	// Like driver.BatchCursor, fail the getMore if ctx has expired but leave the cursor open.
+	if tcsc.ctxErr = ctx.Err(); tcsc.ctxErr != nil {
+		return false
+	}
+	if len(tcsc.batches) == 0 {
+		return false
+	}
+
+	tcsc.batch = tcsc.batches[0]
+	tcsc.batches = tcsc.batches[1:]
+	return true
+}
+
+func (tcsc *testChangeStreamCursor) Batch() *bsoncore.DocumentSequence {
+	return tcsc.batch
+}
+
+func (tcsc *testChangeStreamCursor) Server() driver.Server {
+	return nil
+}
+
+func (tcsc *testChangeStreamCursor) Err() error {
+	if tcsc.err != nil {
+		return tcsc.err
+	}
+	return tcsc.ctxErr
+}
+
+func (tcsc *testChangeStreamCursor) Close(context.Context) error {
+	tcsc.closed = true
+	return nil
+}
+
+func (tcsc *testChangeStreamCursor) PostBatchResumeToken() bsoncore.Document {
+	return tcsc.pbrt
+}
+
+func (tcsc *testChangeStreamCursor) KillCursor(context.Context) error {
+	tcsc.closed = true
+	return nil
+}
+
+// testDeployment is a driver.Deployment whose server selection always fails with err.
+type testDeployment struct {
+	err        error
+	selections int
+}
+
+var _ driver.Deployment = (*testDeployment)(nil)
+
+func (td *testDeployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	td.selections++
+	return nil, td.err
+}
+
+func (td *testDeployment) Kind() description.TopologyKind {
+	return description.ReplicaSet
+}
+
+// commandDeployment is a driver.Deployment that sends all commands over a drivertest.ChannelConn and answers them with
+// the replies passed to newCommandDeployment, in order, so tests can inspect the commands sent by a change stream.
+type commandDeployment struct {
+	conn commandConn
+}
+
+var _ driver.Deployment = (*commandDeployment)(nil)
+var _ driver.Server = (*commandDeployment)(nil)
+
+func newCommandDeployment(wireVersion int32, replies ...bson.D) *commandDeployment {
+	conn := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 32),
+		ReadResp: make(chan []byte, 32),
+		Desc: description.Server{
+			Kind:        description.RSPrimary,
+			WireVersion: &description.VersionRange{Min: 6, Max: wireVersion},
+		},
+	}
+	cd := &commandDeployment{conn: commandConn{conn}}
+	cd.addReplies(replies...)
+	return cd
+}
+
+// commandConn is a drivertest.ChannelConn that fails reads with the context error if ctx expires before a reply is
+// available, like a network connection.
+type commandConn struct {
+	*drivertest.ChannelConn
+}
+
+func (cc commandConn) ReadWireMessage(ctx context.Context, dst []byte) ([]byte, error) {
+	select {
+	case wm := <-cc.ReadResp:
+		return append(dst[:0], wm...), nil
+	case <-ctx.Done():
+		return dst[:0], ctx.Err()
+	}
+}
+
+// addReplies queues replies to be returned after the replies that are already queued.
+func (cd *commandDeployment) addReplies(replies ...bson.D) {
+	for _, reply := range replies {
+		doc, _ := bson.Marshal(reply)
+		cd.conn.ReadResp <- drivertest.MakeReply(doc)
+	}
+}
+
+func (cd *commandDeployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return cd, nil
+}
+
+func (cd *commandDeployment) Kind() description.TopologyKind {
+	return description.ReplicaSet
+}
+
+func (cd *commandDeployment) Connection(context.Context) (driver.Connection, error) {
+	return cd.conn, nil
+}
+
+func (cd *commandDeployment) RTTMonitor() driver.RTTMonitor {
+	return &internal.ZeroRTTMonitor{}
+}
+
+// commands returns the commands sent since the last call.
+func (cd *commandDeployment) commands(t testing.TB) []bsoncore.Document {
+	t.Helper()
+
+	var cmds []bsoncore.Document
+	for {
+		select {
+		case wm := <-cd.conn.Written:
+			cmd, err := drivertest.GetCommandFromMsgWireMessage(wm)
+			assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+			cmds = append(cmds, cmd)
+		default:
+			return cmds
+		}
+	}
+}
+
+// newCursorReply creates a reply to an aggregate or getMore command that returns a cursor with the given ID and batch.
+func newCursorReply(id int64, batchField string, batch ...bson.D) bson.D {
+	arr := bson.A{}
+	for _, doc := range batch {
+		arr = append(arr, doc)
+	}
+	return bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{{"id", id}, {"ns", "db.coll"}, {batchField, arr}}},
+	}
+}
+
+// newCommandChangeStream opens a change stream on the collection db.coll whose commands are sent to deployment.
+func newCommandChangeStream(t testing.TB, deployment *commandDeployment, opts *options.ChangeStreamOptions) (*ChangeStream, error) {
+	t.Helper()
+
+	client := setupClient()
+	client.sessionPool = session.NewPool(nil)
+	// Disable retryable reads so that each aggregate attempt sends exactly one command.
+	client.retryReads = false
+	config := changeStreamConfig{
+		readPreference: readpref.Primary(),
+		client:         client,
+		registry:       bson.DefaultRegistry,
+		streamType:     CollectionStream,
+		collectionName: "coll",
+		databaseName:   "db",
+	}
+	return newChangeStream(bgCtx, config, Pipeline{}, opts.SetDeployment(deployment))
+}
+
+// changeStreamStage returns the $changeStream stage of the pipeline of an aggregate command.
+func changeStreamStage(cmd bsoncore.Document) bsoncore.Document {
+	return cmd.Lookup("pipeline").Array().Index(0).Document().Lookup("$changeStream").Document()
+}
+
+// newTestEvent creates a change event document with a resume token containing the given data.
+func newTestEvent(data string, elems ...bson.E) bson.D {
+	return append(bson.D{{"_id", bson.D{{"_data", data}}}}, elems...)
+}
+
 func TestChangeStream(t *testing.T) {
 	t.Run("nil cursor", func(t *testing.T) {
 		cs := &ChangeStream{}
@@ -25,5 +262,1808 @@ func TestChangeStream(t *testing.T) {
 		assert.Nil(t, err, "change stream error: %v", err)
 		err = cs.Close(bgCtx)
 		assert.Nil(t, err, "Close error: %v", err)
+		assert.Nil(t, cs.WireVersion(), "expected nil WireVersion, got %v", cs.WireVersion())
+	})
+	t.Run("resume token encoding", func(t *testing.T) {
+		token, err := bson.Marshal(bson.D{{"_data", "825F1B0C34000000012B022C0100296E5A1004"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		encoded := EncodeResumeToken(token)
+		decoded, err := DecodeResumeToken(encoded)
+		assert.Nil(t, err, "DecodeResumeToken error: %v", err)
+		assert.Equal(t, bson.Raw(token), decoded, "expected token %v, got %v", bson.Raw(token), decoded)
+
+		_, err = DecodeResumeToken("not base64!")
+		assert.NotNil(t, err, "expected error decoding invalid base64, got nil")
+		_, err = DecodeResumeToken(EncodeResumeToken(bson.Raw{0x01, 0x02}))
+		assert.NotNil(t, err, "expected error decoding invalid BSON, got nil")
 	})
+	t.Run("fallback operation time", func(t *testing.T) {
+		fallback := &primitive.Timestamp{T: 1, I: 1}
+		token := bson.D{{"_data", "token"}}
+		notFoundErr := CommandError{Code: 286, Name: "ChangeStreamHistoryLost"}
+
+		testCases := []struct {
+			name     string
+			opts     *options.ChangeStreamOptions
+			err      error
+			expected bool
+		}{
+			{"token not found", options.ChangeStream().SetResumeAfter(token).SetFallbackStartAtOperationTime(fallback), notFoundErr, true},
+			{"start after not found", options.ChangeStream().SetStartAfter(token).SetFallbackStartAtOperationTime(fallback), notFoundErr, true},
+			{"cross cluster", options.ChangeStream().SetResumeAfter(token).SetCrossClusterFallback(fallback),
+				CommandError{Code: 260, Name: "InvalidResumeToken"}, true},
+			{"no fallback", options.ChangeStream().SetResumeAfter(token), notFoundErr, false},
+			{"no token", options.ChangeStream().SetFallbackStartAtOperationTime(fallback), notFoundErr, false},
+			{"other error", options.ChangeStream().SetResumeAfter(token).SetFallbackStartAtOperationTime(fallback), CommandError{Code: 13}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cs := &ChangeStream{options: tc.opts, err: tc.err}
+				got := cs.canUseFallbackOperationTime()
+				assert.Equal(t, tc.expected, got, "expected canUseFallbackOperationTime %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("fallback aggregate", func(t *testing.T) {
+		fallback := primitive.Timestamp{T: 1, I: 1}
+		notFoundErr := bson.D{{"ok", 0}, {"code", 286}, {"errmsg", "history lost"}}
+		opts := func() *options.ChangeStreamOptions {
+			return options.ChangeStream().SetResumeAfter(bson.D{{"_data", "1"}}).SetFallbackStartAtOperationTime(&fallback)
+		}
+
+		t.Run("supported", func(t *testing.T) {
+			deployment := newCommandDeployment(17, notFoundErr, newCursorReply(1, "firstBatch"))
+			_, err := newCommandChangeStream(t, deployment, opts())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+
+			cmds := deployment.commands(t)
+			assert.Equal(t, 2, len(cmds), "expected 2 aggregate commands, got %v", len(cmds))
+			stage := changeStreamStage(cmds[1])
+			_, err = stage.LookupErr("resumeAfter")
+			assert.NotNil(t, err, "expected no resumeAfter in $changeStream stage %v", stage)
+			secs, inc := stage.Lookup("startAtOperationTime").Timestamp()
+			got := primitive.Timestamp{T: secs, I: inc}
+			assert.Equal(t, fallback, got, "expected startAtOperationTime %v, got %v", fallback, got)
+		})
+		t.Run("unsupported", func(t *testing.T) {
+			deployment := newCommandDeployment(6, notFoundErr)
+			_, err := newCommandChangeStream(t, deployment, opts())
+			expected := UnsupportedChangeStreamOptionError{Option: "startAtOperationTime", MinWireVersion: 7, Have: 6}
+			assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+		})
+	})
+	t.Run("show system events", func(t *testing.T) {
+		for _, show := range []bool{true, false} {
+			cs := &ChangeStream{options: options.MergeChangeStreamOptions(options.ChangeStream().SetShowSystemEvents(show))}
+			doc, err := cs.createPipelineOptionsDoc()
+			assert.Nil(t, err, "createPipelineOptionsDoc error: %v", err)
+
+			got, ok := doc.Lookup("showSystemEvents").BooleanOK()
+			assert.True(t, ok, "expected showSystemEvents in %v", doc)
+			assert.Equal(t, show, got, "expected showSystemEvents %v, got %v", show, got)
+		}
+
+		cs := &ChangeStream{options: options.ChangeStream()}
+		doc, err := cs.createPipelineOptionsDoc()
+		assert.Nil(t, err, "createPipelineOptionsDoc error: %v", err)
+		_, err = doc.LookupErr("showSystemEvents")
+		assert.NotNil(t, err, "expected showSystemEvents to be omitted from %v", doc)
+	})
+	t.Run("exclude system namespaces", func(t *testing.T) {
+		matchStage := func(dbs ...string) bson.Raw {
+			b, err := bson.Marshal(bson.D{{"$match", bson.D{{"ns.db", bson.D{{"$nin", dbs}}}}}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+		testCases := []struct {
+			name       string
+			streamType StreamType
+			opts       *options.ChangeStreamOptions
+			expected   bson.Raw
+		}{
+			{"not set", ClientStream, options.ChangeStream(), nil},
+			{"false", ClientStream, options.ChangeStream().SetExcludeSystemNamespaces(false), nil},
+			{"default databases", ClientStream, options.ChangeStream().SetExcludeSystemNamespaces(true),
+				matchStage("admin", "config", "local")},
+			{"custom databases", ClientStream,
+				options.ChangeStream().SetExcludeSystemNamespaces(true).SetSystemDatabases("local", "internal"),
+				matchStage("local", "internal")},
+			{"database stream", DatabaseStream, options.ChangeStream().SetExcludeSystemNamespaces(true), nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cs := &ChangeStream{options: tc.opts, streamType: tc.streamType, registry: bson.DefaultRegistry}
+				err := cs.buildPipelineSlice(bson.A{bson.D{{"$project", bson.D{{"x", 1}}}}})
+				assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+
+				if tc.expected == nil {
+					assert.Equal(t, 2, len(cs.pipelineSlice), "expected 2 stages, got %v", len(cs.pipelineSlice))
+					return
+				}
+				assert.Equal(t, 3, len(cs.pipelineSlice), "expected 3 stages, got %v", len(cs.pipelineSlice))
+				got := bson.Raw(cs.pipelineSlice[1])
+				assert.Equal(t, tc.expected, got, "expected stage %v, got %v", tc.expected, got)
+			})
+		}
+	})
+	t.Run("operation types", func(t *testing.T) {
+		opts := options.ChangeStream().
+			SetExcludeSystemNamespaces(true).
+			SetOperationTypes(options.OperationTypeInsert, options.OperationTypeUpdate)
+		cs := &ChangeStream{options: opts, streamType: ClientStream, registry: bson.DefaultRegistry}
+		err := cs.buildPipelineSlice(bson.A{bson.D{{"$project", bson.D{{"x", 1}}}}})
+		assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+		assert.Equal(t, 4, len(cs.pipelineSlice), "expected 4 stages, got %v", len(cs.pipelineSlice))
+
+		expected, err := bson.Marshal(bson.D{{"$match", bson.D{{"operationType", bson.D{{"$in", bson.A{"insert", "update"}}}}}}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		got := bson.Raw(cs.pipelineSlice[2])
+		assert.Equal(t, bson.Raw(expected), got, "expected stage %v, got %v", bson.Raw(expected), got)
+		_, err = bson.Raw(cs.pipelineSlice[3]).LookupErr("$project")
+		assert.Nil(t, err, "expected user stage last, got %v", bson.Raw(cs.pipelineSlice[3]))
+
+		t.Run("empty", func(t *testing.T) {
+			cs := &ChangeStream{options: options.ChangeStream().SetOperationTypes(), registry: bson.DefaultRegistry}
+			err := cs.buildPipelineSlice(bson.A{})
+			assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+			assert.Equal(t, 1, len(cs.pipelineSlice), "expected 1 stage, got %v", len(cs.pipelineSlice))
+		})
+	})
+	t.Run("resume pipeline func", func(t *testing.T) {
+		var prev []bson.D
+		opts := options.ChangeStream().
+			SetOperationTypes(options.OperationTypeInsert).
+			SetSplitLargeChanges(true).
+			SetResumePipelineFunc(func(stages []bson.D) []bson.D {
+				prev = stages
+				return append(stages, bson.D{{"$addFields", bson.D{{"resumed", true}}}})
+			})
+		cs := &ChangeStream{options: opts, registry: bson.DefaultRegistry}
+		err := cs.buildPipelineSlice(bson.A{bson.D{{"$project", bson.D{{"x", 1}}}}})
+		assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+
+		err = cs.rewriteUserStages()
+		assert.Nil(t, err, "rewriteUserStages error: %v", err)
+		expectedPrev := []bson.D{{{"$project", bson.D{{"x", int32(1)}}}}}
+		assert.Equal(t, expectedPrev, prev, "expected stages %v, got %v", expectedPrev, prev)
+		assert.Equal(t, 5, len(cs.pipelineSlice), "expected 5 stages, got %v", len(cs.pipelineSlice))
+		for i, name := range []string{"$changeStream", "$match", "$project", "$addFields", "$changeStreamSplitLargeEvent"} {
+			_, err = bson.Raw(cs.pipelineSlice[i]).LookupErr(name)
+			assert.Nil(t, err, "expected %v at stage %d, got %v", name, i, bson.Raw(cs.pipelineSlice[i]))
+		}
+
+		// A second resume sees the stages returned by the previous call.
+		err = cs.rewriteUserStages()
+		assert.Nil(t, err, "rewriteUserStages error: %v", err)
+		assert.Equal(t, 2, len(prev), "expected 2 stages, got %v", len(prev))
+		assert.Equal(t, 6, len(cs.pipelineSlice), "expected 6 stages, got %v", len(cs.pipelineSlice))
+
+		cs.options.ResumePipelineFunc = func([]bson.D) []bson.D {
+			return []bson.D{{{"$changeStream", bson.D{}}}}
+		}
+		err = cs.rewriteUserStages()
+		assert.True(t, errors.Is(err, ErrInvalidChangeStreamStage),
+			"expected error %v, got %v", ErrInvalidChangeStreamStage, err)
+	})
+	t.Run("decode event", func(t *testing.T) {
+		update := newTestEvent("1",
+			bson.E{"operationType", "update"},
+			bson.E{"clusterTime", primitive.Timestamp{T: 10, I: 1}},
+			bson.E{"ns", bson.D{{"db", "db"}, {"coll", "coll"}}},
+			bson.E{"documentKey", bson.D{{"_id", 1}}},
+			bson.E{"fullDocument", nil},
+			bson.E{"updateDescription", bson.D{
+				{"updatedFields", bson.D{{"x", 2}}},
+				{"removedFields", bson.A{"y"}},
+				{"truncatedArrays", bson.A{bson.D{{"field", "z"}, {"newSize", 1}}}},
+			}},
+			bson.E{"unknownField", "ignored"},
+		)
+		cursor := newTestChangeStreamCursor(t, []bson.D{update, newTestEvent("2", bson.E{"operationType", "insert"})})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream(), registry: bson.DefaultRegistry}
+
+		var ev ChangeEvent
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		err := cs.DecodeEvent(&ev)
+		assert.Nil(t, err, "DecodeEvent error: %v", err)
+		assert.Equal(t, "update", ev.OperationType, "expected operationType update, got %v", ev.OperationType)
+		assert.Equal(t, primitive.Timestamp{T: 10, I: 1}, ev.ClusterTime, "expected clusterTime {10 1}, got %v", ev.ClusterTime)
+		assert.Equal(t, ChangeEventNamespace{DB: "db", Coll: "coll"}, ev.Ns, "expected ns db.coll, got %v", ev.Ns)
+		assert.Equal(t, int32(1), ev.DocumentKey.Lookup("_id").Int32(), "expected documentKey _id 1, got %v", ev.DocumentKey)
+		assert.Equal(t, 0, len(ev.FullDocument), "expected empty fullDocument, got %v", ev.FullDocument)
+		assert.NotNil(t, ev.UpdateDescription, "expected updateDescription, got nil")
+		assert.Equal(t, []string{"y"}, ev.UpdateDescription.RemovedFields,
+			"expected removedFields [y], got %v", ev.UpdateDescription.RemovedFields)
+		expectedArrays := []ChangeEventTruncatedArray{{Field: "z", NewSize: 1}}
+		assert.Equal(t, expectedArrays, ev.UpdateDescription.TruncatedArrays,
+			"expected truncatedArrays %v, got %v", expectedArrays, ev.UpdateDescription.TruncatedArrays)
+
+		// Fields from the previous event must not leak into the next one.
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		err = cs.DecodeEvent(&ev)
+		assert.Nil(t, err, "DecodeEvent error: %v", err)
+		assert.Equal(t, "insert", ev.OperationType, "expected operationType insert, got %v", ev.OperationType)
+		assert.Nil(t, ev.UpdateDescription, "expected nil updateDescription, got %v", ev.UpdateDescription)
+		assert.Nil(t, ev.DocumentKey, "expected nil documentKey, got %v", ev.DocumentKey)
+	})
+	t.Run("parallel each", func(t *testing.T) {
+		newEvents := func() []bson.D {
+			var events []bson.D
+			for i := 1; i <= 6; i++ {
+				events = append(events, newTestEvent(strconv.Itoa(i), bson.E{"n", int32(i)}))
+			}
+			return events
+		}
+		token := func(data string) bson.Raw {
+			b, err := bson.Marshal(bson.D{{"_data", data}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return b
+		}
+
+		t.Run("processes all events", func(t *testing.T) {
+			events := newEvents()
+			cursor := newTestChangeStreamCursor(t, events[:3], events[3:])
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+			var mu sync.Mutex
+			var sum int32
+			err := cs.ParallelEach(bgCtx, 3, func(event bson.Raw) error {
+				_ = cs.CommittedResumeToken()
+				mu.Lock()
+				defer mu.Unlock()
+				sum += event.Lookup("n").Int32()
+				return nil
+			})
+			assert.Nil(t, err, "ParallelEach error: %v", err)
+			assert.Equal(t, int32(21), sum, "expected sum 21, got %v", sum)
+			assert.Equal(t, token("6"), cs.CommittedResumeToken(),
+				"expected committed token %v, got %v", token("6"), cs.CommittedResumeToken())
+		})
+		t.Run("stops on error", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t, newEvents())
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+			fnErr := errors.New("processing error")
+			err := cs.ParallelEach(bgCtx, 2, func(event bson.Raw) error {
+				if event.Lookup("n").Int32() == 3 {
+					return fnErr
+				}
+				return nil
+			})
+			assert.Equal(t, fnErr, err, "expected error %v, got %v", fnErr, err)
+			assert.Equal(t, token("2"), cs.CommittedResumeToken(),
+				"expected committed token %v, got %v", token("2"), cs.CommittedResumeToken())
+		})
+		t.Run("error stops blocked dispatch", func(t *testing.T) {
+			// No reply is queued for the getMore, so the dispatcher blocks until the error cancels it.
+			deployment := newCommandDeployment(17, newCursorReply(1, "firstBatch", newTestEvent("1")))
+			cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+
+			fnErr := errors.New("processing error")
+			err = cs.ParallelEach(bgCtx, 2, func(bson.Raw) error {
+				return fnErr
+			})
+			assert.Equal(t, fnErr, err, "expected error %v, got %v", fnErr, err)
+			assert.Nil(t, cs.CommittedResumeToken(), "expected no committed token, got %v", cs.CommittedResumeToken())
+		})
+		t.Run("invalid worker count", func(t *testing.T) {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t), options: options.ChangeStream()}
+			err := cs.ParallelEach(bgCtx, 0, func(bson.Raw) error { return nil })
+			assert.NotNil(t, err, "expected error for 0 workers, got nil")
+		})
+	})
+	t.Run("resumed to new server", func(t *testing.T) {
+		cs := &ChangeStream{}
+		steps := []struct {
+			addr     address.Address
+			resuming bool
+			expected bool
+		}{
+			{"a:27017", false, false},
+			{"a:27017", true, false},
+			{"b:27017", true, true},
+			{"b:27017", true, false},
+		}
+		for i, step := range steps {
+			cs.recordServerAddress(step.addr, step.resuming)
+			got := cs.ResumedToNewServer()
+			assert.Equal(t, step.expected, got, "expected ResumedToNewServer %v after step %d, got %v", step.expected, i, got)
+		}
+	})
+	t.Run("validate stages", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			strict bool
+			stage  bson.D
+			valid  bool
+		}{
+			{"allowed stage", false, bson.D{{"$match", bson.D{{"x", 1}}}}, true},
+			{"disallowed stage", false, bson.D{{"$lookup", bson.D{{"from", "coll"}}}}, false},
+			{"output stage", false, bson.D{{"$out", "coll"}}, false},
+			{"unknown stage", false, bson.D{{"$futureStage", bson.D{}}}, true},
+			{"strict allowed stage", true, bson.D{{"$project", bson.D{{"x", 1}}}}, true},
+			{"strict unknown stage", true, bson.D{{"$futureStage", bson.D{}}}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				opts := options.ChangeStream().SetStrictPipelineValidation(tc.strict)
+				cs := &ChangeStream{options: opts, registry: bson.DefaultRegistry}
+				err := cs.buildPipelineSlice(bson.A{bson.D{{"$match", bson.D{}}}, tc.stage})
+				if tc.valid {
+					assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, ErrInvalidChangeStreamStage),
+					"expected error %v, got %v", ErrInvalidChangeStreamStage, err)
+				assert.True(t, strings.Contains(err.Error(), tc.stage[0].Key),
+					"expected error to contain %q, got %q", tc.stage[0].Key, err.Error())
+			})
+		}
+	})
+	t.Run("interrupt", func(t *testing.T) {
+		deployment := newCommandDeployment(17, newCursorReply(1, "firstBatch", newTestEvent("1")))
+		cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		_ = deployment.commands(t)
+
+		// Interrupt has no effect when no call to Next is in progress.
+		cs.Interrupt()
+
+		go func() {
+			// The getMore blocks waiting for a reply until it is interrupted.
+			wm := <-deployment.conn.Written
+			deployment.conn.Written <- wm
+			cs.Interrupt()
+		}()
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		err = cs.Err()
+		assert.True(t, errors.Is(err, ErrInterrupted), "expected error %v, got %v", ErrInterrupted, err)
+
+		// The next call resumes from the last event instead of continuing the interrupted cursor.
+		deployment.addReplies(bson.D{{"ok", 1}}, newCursorReply(1, "firstBatch", newTestEvent("2")))
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true after interrupt, got false")
+		err = cs.Err()
+		assert.Nil(t, err, "change stream error: %v", err)
+		cmds := deployment.commands(t)
+		assert.Equal(t, 3, len(cmds), "expected 3 commands, got %v", len(cmds))
+		data := changeStreamStage(cmds[2]).Lookup("resumeAfter", "_data").StringValue()
+		assert.Equal(t, "1", data, "expected resumeAfter for event 1, got %v", data)
+	})
+	t.Run("supported features", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			max      int32
+			expected ChangeStreamFeatures
+		}{
+			{"3.6", 6, ChangeStreamFeatures{}},
+			{"4.2", 8, ChangeStreamFeatures{StartAtOperationTime: true, StartAfter: true}},
+			{"4.4", 9, ChangeStreamFeatures{StartAtOperationTime: true, StartAfter: true, ResumableErrorLabel: true}},
+			{"6.0", 17, ChangeStreamFeatures{StartAtOperationTime: true, StartAfter: true, ResumableErrorLabel: true,
+				PreImages: true, ExpandedEvents: true}},
+			{"7.0", 21, ChangeStreamFeatures{StartAtOperationTime: true, StartAfter: true, ResumableErrorLabel: true,
+				PreImages: true, ExpandedEvents: true, SplitLargeEvents: true}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cs := &ChangeStream{wireVersion: &description.VersionRange{Min: 0, Max: tc.max}}
+				got := cs.SupportedFeatures()
+				assert.Equal(t, tc.expected, got, "expected features %+v, got %+v", tc.expected, got)
+			})
+		}
+
+		got := (&ChangeStream{}).SupportedFeatures()
+		assert.Equal(t, ChangeStreamFeatures{}, got, "expected no features before opening, got %+v", got)
+	})
+	t.Run("in split event", func(t *testing.T) {
+		fragment := func(data string, n, of int32) bson.D {
+			return newTestEvent(data, bson.E{"splitEvent", bson.D{{"fragment", n}, {"of", of}}})
+		}
+		// The second batch simulates the remaining fragments being returned after the first batch ends.
+		cursor := newTestChangeStreamCursor(t,
+			[]bson.D{newTestEvent("1"), fragment("2", 1, 3)},
+			[]bson.D{fragment("3", 2, 3), fragment("4", 3, 3), newTestEvent("5")},
+		)
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+		expected := []bool{false, true, true, false, false}
+		for i, want := range expected {
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true for event %d", i)
+			got := cs.InSplitEvent()
+			assert.Equal(t, want, got, "expected InSplitEvent %v for event %d, got %v", want, i, got)
+		}
+
+		t.Run("resumed between fragments", func(t *testing.T) {
+			deployment := newCommandDeployment(17,
+				newCursorReply(1, "firstBatch", newTestEvent("1"), fragment("2", 1, 3)),
+				bson.D{{"ok", 0}, {"code", 10107}, {"errmsg", "not primary"}, {"errorLabels", bson.A{resumableErrorLabel}}},
+				bson.D{{"ok", 1}},
+				newCursorReply(2, "firstBatch", fragment("3", 2, 3), fragment("4", 3, 3), newTestEvent("5")),
+			)
+			cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+
+			for i, want := range expected {
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true for event %d, got false: %v", i, cs.Err())
+				got := cs.InSplitEvent()
+				assert.Equal(t, want, got, "expected InSplitEvent %v for event %d, got %v", want, i, got)
+			}
+
+			// The change stream resumes after the fragment that was returned before the error.
+			var aggregates []bsoncore.Document
+			for _, cmd := range deployment.commands(t) {
+				if _, err := cmd.LookupErr("aggregate"); err == nil {
+					aggregates = append(aggregates, cmd)
+				}
+			}
+			assert.Equal(t, 2, len(aggregates), "expected 2 aggregate commands, got %v", len(aggregates))
+			data := changeStreamStage(aggregates[1]).Lookup("resumeAfter", "_data").StringValue()
+			assert.Equal(t, "2", data, "expected resumeAfter token 2, got %v", data)
+		})
+	})
+	t.Run("split large changes", func(t *testing.T) {
+		fragment := func(data string, n, of int32, elems ...bson.E) bson.D {
+			return newTestEvent(data, append([]bson.E{{"splitEvent", bson.D{{"fragment", n}, {"of", of}}}}, elems...)...)
+		}
+
+		t.Run("reassembles fragments", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t,
+				[]bson.D{newTestEvent("1"), fragment("2", 1, 3, bson.E{"operationType", "update"})},
+				[]bson.D{fragment("3", 2, 3, bson.E{"fullDocument", bson.D{{"x", 1}}})},
+				[]bson.D{fragment("4", 3, 3, bson.E{"fullDocumentBeforeChange", bson.D{{"x", 0}}}), newTestEvent("5")},
+			)
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream().SetSplitLargeChanges(true)}
+
+			var tokens []string
+			for cs.Next(bgCtx) {
+				assert.False(t, cs.InSplitEvent(), "expected complete event, got fragment %v", cs.Current)
+				tokens = append(tokens, cs.ResumeToken().Lookup("_data").StringValue())
+			}
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+			assert.Equal(t, []string{"1", "4", "5"}, tokens, "expected resume tokens %v, got %v",
+				[]string{"1", "4", "5"}, tokens)
+		})
+		t.Run("merged event", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t, []bson.D{
+				fragment("1", 1, 2, bson.E{"operationType", "update"}),
+				fragment("2", 2, 2, bson.E{"fullDocument", bson.D{{"x", int32(1)}}}),
+			})
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream().SetSplitLargeChanges(true)}
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+			expected, err := bson.Marshal(bson.D{
+				{"_id", bson.D{{"_data", "2"}}},
+				{"operationType", "update"},
+				{"fullDocument", bson.D{{"x", int32(1)}}},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(expected), cs.Current, "expected event %v, got %v", bson.Raw(expected), cs.Current)
+		})
+		t.Run("pipeline stage", func(t *testing.T) {
+			cs := &ChangeStream{options: options.ChangeStream().SetSplitLargeChanges(true), registry: bson.DefaultRegistry}
+			err := cs.buildPipelineSlice(bson.A{bson.D{{"$match", bson.D{}}}})
+			assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+
+			last := bson.Raw(cs.pipelineSlice[len(cs.pipelineSlice)-1])
+			_, err = last.LookupErr("$changeStreamSplitLargeEvent")
+			assert.Nil(t, err, "expected $changeStreamSplitLargeEvent as the last stage, got %v", last)
+		})
+		t.Run("pipeline order", func(t *testing.T) {
+			opts := options.ChangeStream().SetSplitLargeChanges(true).SetOperationTypes(options.OperationTypeInsert).
+				SetResumePipelineFunc(func(prev []bson.D) []bson.D {
+					return append(prev, bson.D{{"$project", bson.D{{"x", 1}}}})
+				})
+			cs := &ChangeStream{options: opts, registry: bson.DefaultRegistry}
+			err := cs.buildPipelineSlice(bson.A{bson.D{{"$match", bson.D{}}}, bson.D{{"$set", bson.D{{"y", 1}}}}})
+			assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+
+			stageNames := func() []string {
+				var names []string
+				for _, stage := range cs.pipelineSlice {
+					names = append(names, stage.Index(0).Key())
+				}
+				return names
+			}
+			expected := []string{"$changeStream", "$match", "$match", "$set", "$changeStreamSplitLargeEvent"}
+			got := stageNames()
+			assert.Equal(t, expected, got, "expected stages %v, got %v", expected, got)
+
+			// Stages added by ResumePipelineFunc are placed before the split stage.
+			err = cs.rewriteUserStages()
+			assert.Nil(t, err, "rewriteUserStages error: %v", err)
+			expected = []string{"$changeStream", "$match", "$match", "$set", "$project", "$changeStreamSplitLargeEvent"}
+			got = stageNames()
+			assert.Equal(t, expected, got, "expected stages %v, got %v", expected, got)
+		})
+		t.Run("user stage", func(t *testing.T) {
+			split := bson.D{{"$changeStreamSplitLargeEvent", bson.D{}}}
+			match := bson.D{{"$match", bson.D{}}}
+			testCases := []struct {
+				name     string
+				option   bool
+				strict   bool
+				pipeline bson.A
+				valid    bool
+			}{
+				{"last stage", false, false, bson.A{match, split}, true},
+				{"not last stage", false, false, bson.A{split, match}, false},
+				{"with option", true, false, bson.A{match, split}, false},
+				{"strict validation", false, true, bson.A{match, split}, true},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					opts := options.ChangeStream().SetSplitLargeChanges(tc.option).SetStrictPipelineValidation(tc.strict)
+					cs := &ChangeStream{options: opts, registry: bson.DefaultRegistry}
+					err := cs.buildPipelineSlice(tc.pipeline)
+					if tc.valid {
+						assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+						return
+					}
+					assert.True(t, errors.Is(err, ErrInvalidChangeStreamStage),
+						"expected error %v, got %v", ErrInvalidChangeStreamStage, err)
+				})
+			}
+		})
+	})
+	t.Run("missing pre-image", func(t *testing.T) {
+		withPreImage := func(data, opType string, preImage interface{}) bson.D {
+			return newTestEvent(data, bson.E{"operationType", opType}, bson.E{"fullDocumentBeforeChange", preImage})
+		}
+		events := []bson.D{
+			withPreImage("1", "update", bson.D{{"x", 1}}),
+			withPreImage("2", "delete", nil),
+			newTestEvent("3", bson.E{"operationType", "replace"}),
+			withPreImage("4", "insert", nil),
+		}
+		preImageErr := CommandError{
+			Code: 47,
+			Name: "NoMatchingDocument",
+			Message: "Change stream was configured to require a pre-image for all update, delete and replace events, " +
+				"but the pre-image was not found",
+		}
+
+		testCases := []struct {
+			name     string
+			fdbc     options.FullDocument
+			skip     bool
+			sent     string
+			expected []string
+		}{
+			{"required with skip", options.Required, true, "whenAvailable", []string{"1", "4"}},
+			{"required without skip", options.Required, false, "required", []string{"1", "2", "3", "4"}},
+			{"whenAvailable with skip", options.WhenAvailable, true, "whenAvailable", []string{"1", "2", "3", "4"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				opts := options.ChangeStream().SetFullDocumentBeforeChange(tc.fdbc).SetSkipMissingPreImage(tc.skip)
+				cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events), options: opts}
+
+				doc, err := cs.createPipelineOptionsDoc()
+				assert.Nil(t, err, "createPipelineOptionsDoc error: %v", err)
+				sent := doc.Lookup("fullDocumentBeforeChange").StringValue()
+				assert.Equal(t, tc.sent, sent, "expected fullDocumentBeforeChange %q, got %q", tc.sent, sent)
+
+				var tokens []string
+				for cs.Next(bgCtx) {
+					tokens = append(tokens, cs.ResumeToken().Lookup("_data").StringValue())
+				}
+				assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+				assert.Equal(t, tc.expected, tokens, "expected resume tokens %v, got %v", tc.expected, tokens)
+			})
+		}
+
+		t.Run("required error", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t)
+			cursor.err = preImageErr
+			opts := options.ChangeStream().SetFullDocumentBeforeChange(options.Required)
+			cs := &ChangeStream{cursor: cursor, options: opts}
+
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+			err := cs.Err()
+			assert.True(t, errors.Is(err, ErrPreImageUnavailable), "expected error %v, got %v", ErrPreImageUnavailable, err)
+			var commandErr CommandError
+			assert.True(t, errors.As(err, &commandErr), "expected error to wrap %T, got %v", CommandError{}, err)
+			assert.Equal(t, int32(47), commandErr.Code, "expected code 47, got %v", commandErr.Code)
+		})
+		t.Run("whenAvailable error", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t)
+			cursor.err = preImageErr
+			opts := options.ChangeStream().SetFullDocumentBeforeChange(options.WhenAvailable)
+			cs := &ChangeStream{cursor: cursor, options: opts}
+
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+			err := cs.Err()
+			assert.False(t, errors.Is(err, ErrPreImageUnavailable), "expected error not to match %v, got %v",
+				ErrPreImageUnavailable, err)
+		})
+		t.Run("aggregate error", func(t *testing.T) {
+			// The error is matched by code, so a message that mentions a post-image is also classified.
+			deployment := newCommandDeployment(17, bson.D{
+				{"ok", 0},
+				{"code", 47},
+				{"errmsg", "Change stream was configured to require a post-image for all update events"},
+			})
+			opts := options.ChangeStream().SetFullDocumentBeforeChange(options.Required)
+
+			_, err := newCommandChangeStream(t, deployment, opts)
+			assert.True(t, errors.Is(err, ErrPreImageUnavailable), "expected error %v, got %v", ErrPreImageUnavailable, err)
+		})
+	})
+	t.Run("terminal error predicate", func(t *testing.T) {
+		// NotPrimary is resumable, but the predicate marks it as terminal so the stream must not try to resume.
+		notPrimaryErr := CommandError{Code: 10107, Name: "NotPrimary"}
+		var predicateErr error
+		opts := options.ChangeStream().SetTerminalErrorPredicate(func(err error) bool {
+			predicateErr = err
+			return true
+		})
+
+		cursor := newTestChangeStreamCursor(t)
+		cursor.err = notPrimaryErr
+		cs := &ChangeStream{cursor: cursor, options: options.MergeChangeStreamOptions(opts)}
+
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		assert.Equal(t, notPrimaryErr, cs.Err(), "expected error %v, got %v", notPrimaryErr, cs.Err())
+		assert.Equal(t, notPrimaryErr, predicateErr, "expected predicate error %v, got %v", notPrimaryErr, predicateErr)
+	})
+	t.Run("current collection UUID", func(t *testing.T) {
+		uuid := primitive.Binary{Subtype: 0x04, Data: []byte("0123456789abcdef")}
+		cursor := newTestChangeStreamCursor(t, []bson.D{
+			newTestEvent("1", bson.E{"operationType", "drop"}, bson.E{"collectionUUID", uuid}),
+			newTestEvent("2", bson.E{"operationType", "insert"}),
+		})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		got, ok := cs.CurrentCollectionUUID()
+		assert.True(t, ok, "expected collectionUUID to be found")
+		assert.Equal(t, uuid, got, "expected collectionUUID %v, got %v", uuid, got)
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		_, ok = cs.CurrentCollectionUUID()
+		assert.False(t, ok, "expected collectionUUID to be absent")
+	})
+	t.Run("current resume token", func(t *testing.T) {
+		pbrt, err := bson.Marshal(bson.D{{"_data", "pbrt"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1")})
+		cursor.pbrt = pbrt
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		expected, err := bson.Marshal(bson.D{{"_data", "1"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		token, ok := cs.CurrentResumeToken()
+		assert.True(t, ok, "expected current resume token to be found")
+		assert.Equal(t, bson.Raw(expected), token, "expected token %v, got %v", bson.Raw(expected), token)
+		assert.Equal(t, bson.Raw(pbrt), cs.ResumeToken(), "expected ResumeToken %v, got %v", bson.Raw(pbrt),
+			cs.ResumeToken())
+
+		cs.Current = nil
+		_, ok = cs.CurrentResumeToken()
+		assert.False(t, ok, "expected current resume token to be absent")
+	})
+	t.Run("allow missing documents", func(t *testing.T) {
+		events := []bson.D{
+			newTestEvent("1", bson.E{"operationType", "update"}, bson.E{"fullDocument", bson.D{{"x", 1}}}),
+			newTestEvent("2", bson.E{"operationType", "delete"}),
+			newTestEvent("3", bson.E{"operationType", "update"}, bson.E{"fullDocument", nil}),
+		}
+		testCases := []struct {
+			name      string
+			opts      *options.ChangeStreamOptions
+			numEvents int
+			err       error
+		}{
+			{"default", options.ChangeStream().SetFullDocument(options.UpdateLookup), 3, nil},
+			{"allowed", options.ChangeStream().SetFullDocument(options.UpdateLookup).SetAllowMissingDocuments(true), 3, nil},
+			{"not update lookup", options.ChangeStream().SetAllowMissingDocuments(false), 3, nil},
+			{"disallowed",
+				options.ChangeStream().SetFullDocument(options.UpdateLookup).SetAllowMissingDocuments(false),
+				2, ErrMissingFullDocument},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cursor := newTestChangeStreamCursor(t, events)
+				cs := &ChangeStream{cursor: cursor, options: tc.opts}
+
+				var numEvents int
+				for cs.Next(bgCtx) {
+					numEvents++
+				}
+				assert.Equal(t, tc.numEvents, numEvents, "expected %v events, got %v", tc.numEvents, numEvents)
+				assert.Equal(t, tc.err, cs.Err(), "expected error %v, got %v", tc.err, cs.Err())
+			})
+		}
+	})
+	t.Run("detect cluster time regression", func(t *testing.T) {
+		events := []bson.D{
+			newTestEvent("1", bson.E{"clusterTime", primitive.Timestamp{T: 2, I: 1}}),
+			newTestEvent("2", bson.E{"clusterTime", primitive.Timestamp{T: 2, I: 1}}),
+			newTestEvent("3"),
+			newTestEvent("4", bson.E{"clusterTime", primitive.Timestamp{T: 1, I: 5}}),
+		}
+		testCases := []struct {
+			name      string
+			opts      *options.ChangeStreamOptions
+			numEvents int
+			wantErr   bool
+		}{
+			{"default", options.ChangeStream(), 4, false},
+			{"enabled", options.ChangeStream().SetDetectClusterTimeRegression(true), 3, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cursor := newTestChangeStreamCursor(t, events[:2], events[2:])
+				cs := &ChangeStream{cursor: cursor, options: tc.opts}
+
+				var numEvents int
+				for cs.Next(bgCtx) {
+					numEvents++
+				}
+				assert.Equal(t, tc.numEvents, numEvents, "expected %v events, got %v", tc.numEvents, numEvents)
+				if !tc.wantErr {
+					assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+					return
+				}
+				assert.True(t, errors.Is(cs.Err(), ErrClusterTimeRegression),
+					"expected error %v, got %v", ErrClusterTimeRegression, cs.Err())
+			})
+		}
+	})
+	t.Run("unread", func(t *testing.T) {
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2")})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+		assert.False(t, cs.Unread(), "expected Unread to return false before Next")
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		firstToken := cs.ResumeToken()
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		second := cs.Current
+
+		assert.True(t, cs.Unread(), "expected Unread to return true, got false")
+		assert.Nil(t, cs.Current, "expected Current to be nil, got %v", cs.Current)
+		assert.Equal(t, firstToken, cs.ResumeToken(), "expected ResumeToken %v, got %v", firstToken, cs.ResumeToken())
+		assert.False(t, cs.Unread(), "expected second Unread to return false")
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.Equal(t, second, cs.Current, "expected event %v, got %v", second, cs.Current)
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		assert.False(t, cs.Unread(), "expected Unread to return false after unsuccessful Next")
+	})
+	t.Run("last getMore used afterClusterTime", func(t *testing.T) {
+		// The opening aggregate sets the session's operation time, so the aggregate sent to resume carries
+		// afterClusterTime.
+		opTime := bson.E{"operationTime", primitive.Timestamp{T: 10, I: 1}}
+		opened := append(newCursorReply(1, "firstBatch", newTestEvent("1")), opTime)
+		deployment := newCommandDeployment(17,
+			opened,
+			bson.D{{"ok", 0}, {"code", 10107}, {"errmsg", "not primary"}, {"errorLabels", bson.A{resumableErrorLabel}}},
+			bson.D{{"ok", 1}},
+			newCursorReply(2, "firstBatch", newTestEvent("2")),
+		)
+		opts := options.ChangeStream().SetReadConcern(readconcern.Majority())
+		cs, err := newCommandChangeStream(t, deployment, opts)
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		assert.False(t, cs.LastGetMoreUsedAfterClusterTime(), "expected false after open, got true")
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.True(t, cs.LastGetMoreUsedAfterClusterTime(), "expected true after resume, got false")
+
+		cmds := deployment.commands(t)
+		resumeCmd := cmds[len(cmds)-1]
+		_, err = resumeCmd.LookupErr("readConcern", "afterClusterTime")
+		assert.Nil(t, err, "expected afterClusterTime in resume aggregate %v", resumeCmd)
+	})
+	t.Run("memory footprint", func(t *testing.T) {
+		cs := &ChangeStream{
+			cursor:  newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2"), newTestEvent("3")}),
+			options: options.ChangeStream(),
+			sess:    &session.Client{},
+		}
+		assert.Equal(t, 0, cs.MemoryFootprint(), "expected footprint 0, got %v", cs.MemoryFootprint())
+
+		event, err := bson.Marshal(newTestEvent("1"))
+		assert.Nil(t, err, "Marshal error: %v", err)
+		token, err := bson.Marshal(bson.D{{"_data", "1"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		// The current event, the two events left in the batch, and the resume token of the current event.
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		expected := 3*len(event) + len(token)
+		assert.Equal(t, expected, cs.MemoryFootprint(), "expected footprint %v, got %v", expected, cs.MemoryFootprint())
+
+		// The previous resume token is kept for Unread.
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		expected = 2*len(event) + 2*len(token)
+		assert.Equal(t, expected, cs.MemoryFootprint(), "expected footprint %v, got %v", expected, cs.MemoryFootprint())
+
+		t.Run("decode errors", func(t *testing.T) {
+			cs := &ChangeStream{options: options.ChangeStream(), decodeErrors: make(chan error, 2)}
+			sizes := []int{10, 20, 30}
+			for _, size := range sizes {
+				cs.sendDecodeError(ChangeStreamDecodeError{Event: make(bson.Raw, size), ResumeToken: make(bson.Raw, 1)})
+			}
+			// The third error is discarded because the buffer is full.
+			assert.Equal(t, 32, cs.MemoryFootprint(), "expected footprint 32, got %v", cs.MemoryFootprint())
+
+			<-cs.DecodeErrors()
+			cs.sendDecodeError(ChangeStreamDecodeError{Event: make(bson.Raw, 40)})
+			assert.Equal(t, 61, cs.MemoryFootprint(), "expected footprint 61, got %v", cs.MemoryFootprint())
+		})
+	})
+	t.Run("current sequence", func(t *testing.T) {
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2")}, []bson.D{newTestEvent("3")})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+		assert.Equal(t, uint64(0), cs.CurrentSequence(), "expected sequence 0, got %v", cs.CurrentSequence())
+
+		var sequences []uint64
+		var unread bool
+		for cs.Next(bgCtx) {
+			sequences = append(sequences, cs.CurrentSequence())
+			if cs.CurrentSequence() == 2 && !unread {
+				unread = cs.Unread()
+				assert.Equal(t, uint64(1), cs.CurrentSequence(), "expected sequence 1 after Unread, got %v",
+					cs.CurrentSequence())
+			}
+		}
+		expected := []uint64{1, 2, 2, 3}
+		assert.Equal(t, expected, sequences, "expected sequences %v, got %v", expected, sequences)
+	})
+	t.Run("on cursor closed", func(t *testing.T) {
+		var calls int
+		opts := options.ChangeStream().SetOnCursorClosed(func() { calls++ })
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1")})
+		cs := &ChangeStream{cursor: cursor, options: opts}
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.Equal(t, 0, calls, "expected 0 calls before the cursor is closed, got %v", calls)
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false, got true")
+		assert.Equal(t, 1, calls, "expected 1 call after the cursor is closed, got %v", calls)
+	})
+	t.Run("full document via find", func(t *testing.T) {
+		existing, err := bson.Marshal(bson.D{{"_id", 1}, {"x", 2}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		var lookups int
+		lookup := func(_ context.Context, documentKey bson.Raw) (bson.Raw, error) {
+			lookups++
+			if documentKey.Lookup("_id").Int32() == 1 {
+				return existing, nil
+			}
+			return nil, nil
+		}
+		cursor := newTestChangeStreamCursor(t, []bson.D{
+			newTestEvent("1", bson.E{"operationType", "update"}, bson.E{"documentKey", bson.D{{"_id", 1}}}),
+			newTestEvent("2", bson.E{"operationType", "update"}, bson.E{"documentKey", bson.D{{"_id", 2}}}),
+			newTestEvent("3", bson.E{"operationType", "insert"}, bson.E{"documentKey", bson.D{{"_id", 3}}}),
+		})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream().SetFullDocumentViaFind(lookup)}
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		fullDoc, ok := cs.Current.Lookup("fullDocument").DocumentOK()
+		assert.True(t, ok, "expected fullDocument in event %v", cs.Current)
+		assert.Equal(t, bson.Raw(existing), fullDoc, "expected fullDocument %v, got %v", bson.Raw(existing), fullDoc)
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		fullDocVal := cs.Current.Lookup("fullDocument")
+		assert.Equal(t, bson.TypeNull, fullDocVal.Type, "expected fullDocument type %v, got %v", bson.TypeNull,
+			fullDocVal.Type)
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		_, err = cs.Current.LookupErr("fullDocument")
+		assert.NotNil(t, err, "expected no fullDocument for insert event, got %v", cs.Current)
+		assert.Equal(t, 2, lookups, "expected 2 lookups, got %v", lookups)
+	})
+	t.Run("initial resume mode", func(t *testing.T) {
+		token := bson.D{{"_data", "1"}}
+		tokenBytes, err := bson.Marshal(token)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		ts := primitive.Timestamp{T: 1, I: 2}
+		tsBytes, err := bson.Marshal(bson.D{{"startAtOperationTime", ts}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		notFoundErr := bson.D{{"ok", 0}, {"code", 286}, {"errmsg", "history lost"}}
+
+		testCases := []struct {
+			name    string
+			opts    *options.ChangeStreamOptions
+			replies []bson.D
+			mode    string
+			token   bson.Raw
+		}{
+			{"none", options.ChangeStream(), nil, "none", nil},
+			{"resumeAfter", options.ChangeStream().SetResumeAfter(token), nil, "resumeAfter", tokenBytes},
+			{"startAfter", options.ChangeStream().SetStartAfter(token), nil, "startAfter", tokenBytes},
+			{"startAtOperationTime", options.ChangeStream().SetStartAtOperationTime(&ts), nil, "startAtOperationTime", tsBytes},
+			{"fallback", options.ChangeStream().SetResumeAfter(token).SetFallbackStartAtOperationTime(&ts),
+				[]bson.D{notFoundErr}, "startAtOperationTime", tsBytes},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				replies := append(tc.replies,
+					newCursorReply(1, "firstBatch", newTestEvent("2")),
+					bson.D{{"ok", 0}, {"code", errorCursorNotFound}, {"errmsg", "cursor not found"}},
+					bson.D{{"ok", 1}},
+					newCursorReply(1, "firstBatch", newTestEvent("3")))
+				deployment := newCommandDeployment(17, replies...)
+				cs, err := newCommandChangeStream(t, deployment, tc.opts)
+				assert.Nil(t, err, "newChangeStream error: %v", err)
+
+				// Options used when resuming must not change the recorded mode.
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+				mode, got := cs.InitialResumeMode()
+				assert.Equal(t, tc.mode, mode, "expected mode %q, got %q", tc.mode, mode)
+				assert.Equal(t, tc.token, got, "expected token %v, got %v", tc.token, got)
+			})
+		}
+	})
+	t.Run("lenient decode", func(t *testing.T) {
+		type event struct {
+			X int32 `bson:"x"`
+		}
+		events := []bson.D{
+			newTestEvent("1", bson.E{"x", 1}),
+			newTestEvent("2", bson.E{"x", "not an int"}),
+			newTestEvent("3", bson.E{"x", 3}),
+		}
+
+		t.Run("default", func(t *testing.T) {
+			cs := &ChangeStream{
+				cursor:   newTestChangeStreamCursor(t, events),
+				options:  options.ChangeStream(),
+				registry: bson.DefaultRegistry,
+			}
+
+			var evt event
+			assert.True(t, cs.NextDecode(bgCtx, &evt), "expected NextDecode to return true, got false")
+			assert.Equal(t, int32(1), evt.X, "expected x 1, got %v", evt.X)
+			assert.False(t, cs.NextDecode(bgCtx, &evt), "expected NextDecode to return false, got true")
+			assert.NotNil(t, cs.Err(), "expected decode error, got nil")
+		})
+		t.Run("lenient", func(t *testing.T) {
+			opts := options.ChangeStream().SetLenientDecode(true)
+			cs := &ChangeStream{
+				cursor:       newTestChangeStreamCursor(t, events),
+				options:      opts,
+				registry:     bson.DefaultRegistry,
+				decodeErrors: make(chan error, decodeErrorsBufferSize),
+			}
+
+			var xs []int32
+			for {
+				var evt event
+				if !cs.NextDecode(bgCtx, &evt) {
+					break
+				}
+				xs = append(xs, evt.X)
+			}
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+			assert.Equal(t, []int32{1, 3}, xs, "expected values %v, got %v", []int32{1, 3}, xs)
+
+			err := <-cs.DecodeErrors()
+			decodeErr, ok := err.(ChangeStreamDecodeError)
+			assert.True(t, ok, "expected error type %T, got %T", ChangeStreamDecodeError{}, err)
+			assert.Equal(t, "2", decodeErr.ResumeToken.Lookup("_data").StringValue(),
+				"expected resume token for event 2, got %v", decodeErr.ResumeToken)
+
+			err = cs.Close(bgCtx)
+			assert.Nil(t, err, "Close error: %v", err)
+			_, open := <-cs.DecodeErrors()
+			assert.False(t, open, "expected DecodeErrors channel to be closed")
+		})
+		t.Run("channel created on open", func(t *testing.T) {
+			deployment := newCommandDeployment(17, newCursorReply(0, "firstBatch"))
+			cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+
+			// The channel must be the same one for every caller, so it cannot be created lazily by DecodeErrors.
+			errs := cs.DecodeErrors()
+			assert.NotNil(t, errs, "expected DecodeErrors channel, got nil")
+			err = cs.Close(bgCtx)
+			assert.Nil(t, err, "Close error: %v", err)
+			_, open := <-errs
+			assert.False(t, open, "expected DecodeErrors channel to be closed")
+		})
+	})
+	t.Run("advance", func(t *testing.T) {
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2")}, []bson.D{newTestEvent("3")})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+		assert.True(t, cs.Advance(bgCtx, 0), "expected Advance(0) to return true, got false")
+		assert.Nil(t, cs.Current, "expected Current to be nil, got %v", cs.Current)
+
+		assert.True(t, cs.Advance(bgCtx, 2), "expected Advance(2) to return true, got false")
+		data := cs.ResumeToken().Lookup("_data").StringValue()
+		assert.Equal(t, "2", data, "expected resume token for event 2, got %v", cs.ResumeToken())
+
+		assert.False(t, cs.Advance(bgCtx, 2), "expected Advance(2) to return false, got true")
+		data = cs.ResumeToken().Lookup("_data").StringValue()
+		assert.Equal(t, "3", data, "expected resume token for event 3, got %v", cs.ResumeToken())
+	})
+	t.Run("context with position", func(t *testing.T) {
+		ts := primitive.Timestamp{T: 10, I: 2}
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1", bson.E{"clusterTime", ts})})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+		_, ok := ChangeStreamPositionFromContext(bgCtx)
+		assert.False(t, ok, "expected no position in background context")
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		ctx := cs.ContextWithPosition(bgCtx)
+		pos, ok := ChangeStreamPositionFromContext(ctx)
+		assert.True(t, ok, "expected position in context")
+		assert.Equal(t, ts, pos.ClusterTime, "expected ClusterTime %v, got %v", ts, pos.ClusterTime)
+		assert.Equal(t, cs.ResumeToken(), pos.ResumeToken, "expected ResumeToken %v, got %v", cs.ResumeToken(),
+			pos.ResumeToken)
+	})
+	t.Run("sample rate", func(t *testing.T) {
+		events := make([]bson.D, 0, 100)
+		for i := 0; i < 100; i++ {
+			events = append(events, newTestEvent(strconv.Itoa(i)))
+		}
+		sample := func(opts *options.ChangeStreamOptions) []string {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events), options: opts}
+			var got []string
+			for cs.Next(bgCtx) {
+				got = append(got, cs.ResumeToken().Lookup("_data").StringValue())
+			}
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+			data := cs.ResumeToken().Lookup("_data").StringValue()
+			assert.Equal(t, "99", data, "expected resume token for event 99, got %v", cs.ResumeToken())
+			return got
+		}
+
+		all := sample(options.ChangeStream().SetSampleRate(1))
+		assert.Equal(t, 100, len(all), "expected 100 events, got %v", len(all))
+		none := sample(options.ChangeStream().SetSampleRate(0))
+		assert.Equal(t, 0, len(none), "expected 0 events, got %v", len(none))
+
+		first := sample(options.ChangeStream().SetSampleRate(0.5).SetSampleSeed(1))
+		second := sample(options.ChangeStream().SetSampleRate(0.5).SetSampleSeed(1))
+		assert.True(t, len(first) > 0 && len(first) < 100, "expected some events to be skipped, got %v", len(first))
+		assert.Equal(t, first, second, "expected events %v, got %v", first, second)
+	})
+	t.Run("time since last event", func(t *testing.T) {
+		now := time.Unix(1000, 0)
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1")})
+		cs := &ChangeStream{cursor: cursor, options: options.ChangeStream(), now: func() time.Time { return now }}
+
+		got := cs.TimeSinceLastEvent()
+		assert.Equal(t, time.Duration(0), got, "expected 0 before the first event, got %v", got)
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		now = now.Add(5 * time.Second)
+		got = cs.TimeSinceLastEvent()
+		assert.Equal(t, 5*time.Second, got, "expected %v, got %v", 5*time.Second, got)
+
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false, got true")
+		now = now.Add(time.Second)
+		got = cs.TimeSinceLastEvent()
+		assert.Equal(t, 6*time.Second, got, "expected %v, got %v", 6*time.Second, got)
+	})
+	t.Run("get more latency hook", func(t *testing.T) {
+		// Each call to the clock advances it by one second, so each cursor fetch appears to take one second.
+		now := time.Unix(1000, 0)
+		clock := func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+		var durations []time.Duration
+		var docs []int
+		opts := options.ChangeStream().SetGetMoreLatencyHook(func(d time.Duration, n int) {
+			durations = append(durations, d)
+			docs = append(docs, n)
+		})
+		cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2")}, []bson.D{newTestEvent("3")})
+		cs := &ChangeStream{cursor: cursor, options: opts, now: clock}
+
+		for cs.Next(bgCtx) {
+		}
+		assert.Equal(t, []int{2, 1, 0}, docs, "expected document counts [2 1 0], got %v", docs)
+		expected := []time.Duration{time.Second, time.Second, time.Second}
+		assert.Equal(t, expected, durations, "expected durations %v, got %v", expected, durations)
+	})
+	t.Run("raw event capture", func(t *testing.T) {
+		var captured [][]byte
+		opts := options.ChangeStream().SetRawEventCapture(func(b []byte) {
+			captured = append(captured, b)
+		})
+		batches := [][]bson.D{{newTestEvent("1"), newTestEvent("2")}, {newTestEvent("3")}}
+		cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, batches...), options: opts}
+
+		var events []bson.Raw
+		for cs.Next(bgCtx) {
+			events = append(events, append(bson.Raw(nil), cs.Current...))
+		}
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		assert.Equal(t, 3, len(captured), "expected 3 captured events, got %v", len(captured))
+		for i, event := range events {
+			assert.Equal(t, []byte(event), captured[i], "expected captured event %v, got %v", event, bson.Raw(captured[i]))
+		}
+
+		// The captured bytes do not share memory with the batch.
+		captured = nil
+		cs = &ChangeStream{cursor: newTestChangeStreamCursor(t, batches...), options: opts}
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		captured[0][len(captured[0])-3] = 'x'
+		assert.Equal(t, events[0], cs.Current, "expected event %v, got %v", events[0], cs.Current)
+	})
+	t.Run("checkpoint on empty batch", func(t *testing.T) {
+		var checkpoints []bson.Raw
+		opts := options.ChangeStream().SetCheckpointOnEmptyBatch(func(token bson.Raw) {
+			checkpoints = append(checkpoints, token)
+		})
+		cursor := newTestChangeStreamCursor(t)
+		cs := &ChangeStream{cursor: cursor, options: opts}
+
+		first := bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "_data", "1"))
+		second := bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "_data", "2"))
+		for _, pbrt := range []bsoncore.Document{first, first, second} {
+			cursor.pbrt = pbrt
+			cs.updatePbrtFromCommand()
+		}
+		expected := []bson.Raw{bson.Raw(first), bson.Raw(second)}
+		assert.Equal(t, expected, checkpoints, "expected checkpoints %v, got %v", expected, checkpoints)
+	})
+	t.Run("pipe", func(t *testing.T) {
+		events := []bson.D{newTestEvent("1", bson.E{"x", int32(1)}), newTestEvent("2", bson.E{"x", int32(2)})}
+
+		t.Run("ndjson", func(t *testing.T) {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events), options: options.ChangeStream()}
+			var buf bytes.Buffer
+			err := cs.Pipe(bgCtx, &buf, FormatNDJSON)
+			assert.Nil(t, err, "Pipe error: %v", err)
+
+			expected := `{"_id":{"_data":"1"},"x":1}` + "\n" +
+				`{"_id":{"_data":"2"},"x":2}` + "\n" +
+				`{"resumeToken":{"_data":"2"}}` + "\n"
+			assert.Equal(t, expected, buf.String(), "expected output %q, got %q", expected, buf.String())
+		})
+		t.Run("canonical json", func(t *testing.T) {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events[:1]), options: options.ChangeStream()}
+			var buf bytes.Buffer
+			err := cs.Pipe(bgCtx, &buf, FormatCanonicalJSON)
+			assert.Nil(t, err, "Pipe error: %v", err)
+
+			expected := `{"_id":{"_data":"1"},"x":{"$numberInt":"1"}}` + "\n" + `{"resumeToken":{"_data":"1"}}` + "\n"
+			assert.Equal(t, expected, buf.String(), "expected output %q, got %q", expected, buf.String())
+		})
+		t.Run("bson", func(t *testing.T) {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events), options: options.ChangeStream()}
+			var buf bytes.Buffer
+			err := cs.Pipe(bgCtx, &buf, FormatBSON)
+			assert.Nil(t, err, "Pipe error: %v", err)
+
+			docs, err := (&bsoncore.DocumentSequence{Style: bsoncore.SequenceStyle, Data: buf.Bytes()}).Documents()
+			assert.Nil(t, err, "Documents error: %v", err)
+			assert.Equal(t, 3, len(docs), "expected 3 documents, got %v", len(docs))
+			token := bson.Raw(docs[2]).Lookup("resumeToken", "_data").StringValue()
+			assert.Equal(t, "2", token, "expected trailer resume token 2, got %v", token)
+		})
+		t.Run("invalid format", func(t *testing.T) {
+			cs := &ChangeStream{cursor: newTestChangeStreamCursor(t, events), options: options.ChangeStream()}
+			err := cs.Pipe(bgCtx, &bytes.Buffer{}, StreamFormat(10))
+			assert.NotNil(t, err, "expected error, got nil")
+		})
+	})
+	t.Run("custom deployment", func(t *testing.T) {
+		selectErr := errors.New("scripted server selection error")
+		deployment := &testDeployment{err: selectErr}
+		coll := setupColl("foo")
+
+		_, err := coll.Watch(bgCtx, Pipeline{}, options.ChangeStream().SetDeployment(deployment))
+		assert.Equal(t, selectErr, err, "expected error %v, got %v", selectErr, err)
+		assert.Equal(t, 1, deployment.selections, "expected 1 server selection, got %v", deployment.selections)
+	})
+	t.Run("watch forever", func(t *testing.T) {
+		now := time.Unix(1000, 0)
+		opTime := primitive.Timestamp{T: 2000, I: 5}
+		historyLost := bson.D{{"ok", 0}, {"code", 286}, {"errmsg", "history lost"}}
+		historyLostAt := append(historyLost, bson.E{"operationTime", opTime})
+
+		testCases := []struct {
+			name     string
+			replies  []bson.D
+			restarts []time.Time
+			opTime   *primitive.Timestamp
+			expected int32
+		}{
+			{"operation time", []bson.D{historyLostAt}, []time.Time{time.Unix(2000, 0)}, &opTime, 0},
+			{"no operation time", []bson.D{historyLost}, []time.Time{now}, nil, 0},
+			{"other error", []bson.D{{{"ok", 0}, {"code", 13}, {"errmsg", "unauthorized"}}}, nil, nil, 13},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				replies := append([]bson.D{newCursorReply(1, "firstBatch", newTestEvent("1"))}, tc.replies...)
+				replies = append(replies, bson.D{{"ok", 1}}, newCursorReply(1, "firstBatch", newTestEvent("2")))
+				deployment := newCommandDeployment(17, replies...)
+				cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+				assert.Nil(t, err, "newChangeStream error: %v", err)
+				var restarts []time.Time
+				cs.now = func() time.Time { return now }
+				cs.onRestart = func(at time.Time) { restarts = append(restarts, at) }
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+				_ = deployment.commands(t)
+
+				got := cs.Next(bgCtx)
+				assert.Equal(t, tc.restarts, restarts, "expected restarts %v, got %v", tc.restarts, restarts)
+				if tc.expected != 0 {
+					assert.False(t, got, "expected Next to return false, got true")
+					var cmdErr CommandError
+					assert.True(t, errors.As(cs.Err(), &cmdErr), "expected CommandError, got %v", cs.Err())
+					assert.Equal(t, tc.expected, cmdErr.Code, "expected error code %v, got %v", tc.expected, cmdErr.Code)
+					return
+				}
+				assert.True(t, got, "expected Next to return true, got false")
+
+				// The restarted stream starts at the server's operation time rather than from the lost resume token.
+				cmds := deployment.commands(t)
+				stage := changeStreamStage(cmds[len(cmds)-1])
+				for _, field := range []string{"resumeAfter", "startAfter"} {
+					_, err := stage.LookupErr(field)
+					assert.NotNil(t, err, "expected no %v in $changeStream stage %v", field, stage)
+				}
+				ts, err := stage.LookupErr("startAtOperationTime")
+				if tc.opTime == nil {
+					assert.NotNil(t, err, "expected no startAtOperationTime in $changeStream stage %v", stage)
+					return
+				}
+				assert.Nil(t, err, "expected startAtOperationTime in $changeStream stage %v", stage)
+				secs, inc := ts.Timestamp()
+				gotTime := primitive.Timestamp{T: secs, I: inc}
+				assert.Equal(t, *tc.opTime, gotTime, "expected startAtOperationTime %v, got %v", *tc.opTime, gotTime)
+			})
+		}
+	})
+	t.Run("watch forever missing resume token", func(t *testing.T) {
+		now := time.Unix(1000, 0)
+		deployment := newCommandDeployment(17,
+			newCursorReply(1, "firstBatch", bson.D{{"x", 1}}, newTestEvent("1")),
+			bson.D{{"ok", 1}},
+			newCursorReply(1, "firstBatch", newTestEvent("2")))
+		cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		var restarts []time.Time
+		cs.now = func() time.Time { return now }
+		cs.onRestart = func(at time.Time) { restarts = append(restarts, at) }
+
+		// The event without a resume token is returned and the change stream restarts, discarding the rest of the batch.
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.Nil(t, cs.Err(), "expected no error, got %v", cs.Err())
+		x := cs.Current.Lookup("x").Int32()
+		assert.Equal(t, int32(1), x, "expected x 1, got %v", x)
+		assert.Equal(t, []time.Time{now}, restarts, "expected restarts %v, got %v", []time.Time{now}, restarts)
+
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		id := cs.Current.Lookup("_id", "_data").StringValue()
+		assert.Equal(t, "2", id, "expected event 2, got %v", id)
+		cmds := deployment.commands(t)
+		stage := changeStreamStage(cmds[len(cmds)-1])
+		_, err = stage.LookupErr("resumeAfter")
+		assert.NotNil(t, err, "expected no resumeAfter in $changeStream stage %v", stage)
+	})
+	t.Run("watch missing resume token", func(t *testing.T) {
+		deployment := newCommandDeployment(17, newCursorReply(1, "firstBatch", bson.D{{"x", 1}}), bson.D{{"ok", 1}})
+		cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		assert.Equal(t, ErrMissingResumeToken, cs.Err(), "expected error %v, got %v", ErrMissingResumeToken, cs.Err())
+	})
+	t.Run("watch forever restart limit", func(t *testing.T) {
+		historyLost := bson.D{{"ok", 0}, {"code", 286}, {"errmsg", "history lost"}}
+		replies := []bson.D{newCursorReply(1, "firstBatch", newTestEvent("1"))}
+		for i := 0; i < maxRestartsPerNext; i++ {
+			replies = append(replies, historyLost, bson.D{{"ok", 1}}, newCursorReply(1, "firstBatch"))
+		}
+		replies = append(replies, historyLost)
+		deployment := newCommandDeployment(17, replies...)
+		cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		var restarts int
+		cs.onRestart = func(time.Time) { restarts++ }
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		var cmdErr CommandError
+		assert.True(t, errors.As(cs.Err(), &cmdErr), "expected CommandError, got %v", cs.Err())
+		assert.Equal(t, errorHistoryLost, cmdErr.Code, "expected error code %v, got %v", errorHistoryLost, cmdErr.Code)
+		assert.Equal(t, maxRestartsPerNext, restarts, "expected %v restarts, got %v", maxRestartsPerNext, restarts)
+	})
+	t.Run("watch collections validation", func(t *testing.T) {
+		client := setupClient()
+		testCases := []struct {
+			name       string
+			client     *Client
+			namespaces []Namespace
+			pipeline   interface{}
+		}{
+			{"nil client", nil, []Namespace{{"db", "coll"}}, Pipeline{}},
+			{"no namespaces", client, nil, Pipeline{}},
+			{"missing database", client, []Namespace{{"db", "coll"}, {"", "coll"}}, Pipeline{}},
+			{"missing collection", client, []Namespace{{"db", ""}}, Pipeline{}},
+			{"nil pipeline", client, []Namespace{{"db", "coll"}}, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cs, err := WatchCollections(bgCtx, tc.client, tc.namespaces, tc.pipeline)
+				assert.NotNil(t, err, "expected WatchCollections error, got nil")
+				assert.Nil(t, cs, "expected nil change stream, got %v", cs)
+			})
+		}
+	})
+	t.Run("auto recreate on invalidate", func(t *testing.T) {
+		token := bson.D{{"_data", "1"}}
+		invalidate := newTestEvent("1", bson.E{"operationType", "invalidate"})
+		testCases := []struct {
+			name   string
+			token  bson.Raw
+			opTime *primitive.Timestamp
+			field  string
+		}{
+			{"resume token", bson.Raw(bsoncore.NewDocumentBuilder().AppendString("_data", "1").Build()), nil, "startAfter"},
+			{"operation time", nil, &primitive.Timestamp{T: 10, I: 1}, "startAtOperationTime"},
+			{"stop", nil, nil, ""},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				deployment := newCommandDeployment(17,
+					newCursorReply(0, "firstBatch", invalidate),
+					newCursorReply(0, "firstBatch"))
+				var calls int
+				opts := options.ChangeStream().SetAutoRecreateOnInvalidate(func() (bson.Raw, *primitive.Timestamp) {
+					calls++
+					if calls > 1 {
+						// Stop after the recreated stream, which is also closed by the server, has been opened.
+						return nil, nil
+					}
+					return tc.token, tc.opTime
+				})
+				cs, err := newCommandChangeStream(t, deployment, opts)
+				assert.Nil(t, err, "newChangeStream error: %v", err)
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+				opType := cs.Current.Lookup("operationType").StringValue()
+				assert.Equal(t, "invalidate", opType, "expected invalidate event, got %v", opType)
+				assert.Equal(t, 0, calls, "expected no calls before the cursor is closed, got %v", calls)
+
+				assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false, got true")
+				assert.Nil(t, cs.Err(), "expected no error, got %v", cs.Err())
+
+				cmds := deployment.commands(t)
+				if tc.field == "" {
+					assert.Equal(t, 1, calls, "expected 1 call, got %v", calls)
+					assert.Equal(t, 1, len(cmds), "expected 1 aggregate command, got %v", len(cmds))
+					return
+				}
+				assert.Equal(t, 2, calls, "expected 2 calls, got %v", calls)
+				assert.Equal(t, 2, len(cmds), "expected 2 aggregate commands, got %v", len(cmds))
+
+				// The recreated stream must start from the returned position rather than the options it was opened
+				// with.
+				stage := changeStreamStage(cmds[1])
+				for _, field := range []string{"resumeAfter", "startAfter", "startAtOperationTime"} {
+					_, err := stage.LookupErr(field)
+					assert.Equal(t, field == tc.field, err == nil, "expected %v in $changeStream stage %v: %v",
+						field, stage, field == tc.field)
+				}
+				if tc.token != nil {
+					got := bson.Raw(stage.Lookup(tc.field).Document())
+					expected, _ := bson.Marshal(token)
+					assert.Equal(t, bson.Raw(expected), got, "expected startAfter %v, got %v", bson.Raw(expected), got)
+					return
+				}
+				ts, inc := stage.Lookup(tc.field).Timestamp()
+				got := primitive.Timestamp{T: ts, I: inc}
+				assert.Equal(t, *tc.opTime, got, "expected startAtOperationTime %v, got %v", *tc.opTime, got)
+			})
+		}
+	})
+	t.Run("last error labels", func(t *testing.T) {
+		networkErr := bson.D{{"ok", 0}, {"code", 6}, {"errmsg", "connection reset"}, {"errorLabels", bson.A{networkErrorLabel}}}
+		deployment := newCommandDeployment(17,
+			newCursorReply(1, "firstBatch", newTestEvent("1")),
+			networkErr,
+			bson.D{{"ok", 1}},
+			newCursorReply(1, "firstBatch", newTestEvent("2")),
+			bson.D{{"ok", 0}, {"code", 13}, {"errmsg", "unauthorized"}})
+		cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		assert.Equal(t, 0, len(cs.LastErrorLabels()), "expected no labels, got %v", cs.LastErrorLabels())
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+		// The labels of a resumed error are kept after the resume succeeds.
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		labels := cs.LastErrorLabels()
+		assert.Equal(t, []string{networkErrorLabel}, labels, "expected labels %v, got %v", []string{networkErrorLabel}, labels)
+		labels[0] = "modified"
+		assert.Equal(t, networkErrorLabel, cs.LastErrorLabels()[0], "expected labels to be copied")
+
+		// An error without labels clears the labels.
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+		assert.Equal(t, 0, len(cs.LastErrorLabels()), "expected no labels, got %v", cs.LastErrorLabels())
+	})
+	t.Run("get more retries", func(t *testing.T) {
+		networkErr := bson.D{{"ok", 0}, {"code", 6}, {"errmsg", "connection reset"}, {"errorLabels", bson.A{networkErrorLabel}}}
+		steppedDownErr := bson.D{{"ok", 0}, {"code", 189}, {"errmsg", "stepped down"}, {"errorLabels", bson.A{resumableErrorLabel}}}
+		nextBatch := newCursorReply(1, "nextBatch", newTestEvent("2"))
+		resumed := []bson.D{{{"ok", 1}}, newCursorReply(1, "firstBatch", newTestEvent("2"))}
+
+		testCases := []struct {
+			name       string
+			retries    int
+			replies    []bson.D
+			getMores   int
+			aggregates int
+		}{
+			{"retries succeed", 2, []bson.D{networkErr, networkErr, nextBatch}, 3, 0},
+			{"retries exhausted", 1, append([]bson.D{networkErr, networkErr}, resumed...), 2, 1},
+			{"not set", 0, append([]bson.D{networkErr}, resumed...), 1, 1},
+			{"non-network error", 2, append([]bson.D{steppedDownErr}, resumed...), 1, 1},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				replies := append([]bson.D{newCursorReply(1, "firstBatch", newTestEvent("1"))}, tc.replies...)
+				deployment := newCommandDeployment(17, replies...)
+				opts := options.ChangeStream()
+				if tc.retries > 0 {
+					opts.SetGetMoreRetries(tc.retries)
+				}
+				cs, err := newCommandChangeStream(t, deployment, opts)
+				assert.Nil(t, err, "newChangeStream error: %v", err)
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+				_ = deployment.commands(t)
+
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+				var getMores, aggregates int
+				for _, cmd := range deployment.commands(t) {
+					switch cmd.Index(0).Key() {
+					case "getMore":
+						// Every retry uses the existing cursor.
+						id := cmd.Index(0).Value().Int64()
+						assert.Equal(t, int64(1), id, "expected getMore for cursor 1, got %v", id)
+						getMores++
+					case "aggregate":
+						aggregates++
+						data := changeStreamStage(cmd).Lookup("resumeAfter", "_data").StringValue()
+						assert.Equal(t, "1", data, "expected resumeAfter for event 1, got %v", data)
+					}
+				}
+				assert.Equal(t, tc.getMores, getMores, "expected %v getMores, got %v", tc.getMores, getMores)
+				assert.Equal(t, tc.aggregates, aggregates, "expected %v aggregates, got %v", tc.aggregates, aggregates)
+				data := cs.ResumeToken().Lookup("_data").StringValue()
+				assert.Equal(t, "2", data, "expected resume token for event 2, got %v", cs.ResumeToken())
+			})
+		}
+	})
+	t.Run("checkpoint", func(t *testing.T) {
+		t.Run("event token", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1"), newTestEvent("2")})
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+			cp := cs.Checkpoint()
+			assert.Equal(t, cs.ResumeToken(), cp.ResumeToken, "expected token %v, got %v", cs.ResumeToken(), cp.ResumeToken)
+			assert.False(t, cp.PostBatchResumeToken, "expected event resume token, got post-batch resume token")
+			assert.Nil(t, cp.OperationTime, "expected no operation time, got %v", cp.OperationTime)
+
+			// The checkpoint is not changed by later events.
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			data := cp.ResumeToken.Lookup("_data").StringValue()
+			assert.Equal(t, "1", data, "expected resume token for event 1, got %v", cp.ResumeToken)
+		})
+		t.Run("post-batch resume token", func(t *testing.T) {
+			pbrt := bsoncore.NewDocumentBuilder().AppendString("_data", "pbrt").Build()
+			cursor := newTestChangeStreamCursor(t)
+			cursor.pbrt = pbrt
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+			cs.updatePbrtFromCommand()
+
+			cp := cs.Checkpoint()
+			assert.Equal(t, bson.Raw(pbrt), cp.ResumeToken, "expected token %v, got %v", bson.Raw(pbrt), cp.ResumeToken)
+			assert.True(t, cp.PostBatchResumeToken, "expected post-batch resume token, got event resume token")
+		})
+		t.Run("operation time", func(t *testing.T) {
+			opTime := &primitive.Timestamp{T: 10, I: 1}
+			cs := &ChangeStream{options: options.ChangeStream().SetStartAtOperationTime(opTime)}
+			cp := cs.Checkpoint()
+			assert.Nil(t, cp.ResumeToken, "expected no resume token, got %v", cp.ResumeToken)
+			assert.Equal(t, opTime, cp.OperationTime, "expected operation time %v, got %v", opTime, cp.OperationTime)
+
+			sessTime := &primitive.Timestamp{T: 20, I: 1}
+			cs = &ChangeStream{options: options.ChangeStream(), sess: &session.Client{OperationTime: sessTime},
+				operationTime: sessTime}
+			cp = cs.Checkpoint()
+			assert.Equal(t, sessTime, cp.OperationTime, "expected operation time %v, got %v", sessTime, cp.OperationTime)
+		})
+		t.Run("serialization", func(t *testing.T) {
+			token, err := bson.Marshal(bson.D{{"_data", "1"}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			cp := options.ChangeStreamCheckpoint{
+				ResumeToken:          token,
+				PostBatchResumeToken: true,
+				OperationTime:        &primitive.Timestamp{T: 10, I: 1},
+			}
+
+			b, err := bson.Marshal(cp)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			var got options.ChangeStreamCheckpoint
+			err = bson.Unmarshal(b, &got)
+			assert.Nil(t, err, "Unmarshal error: %v", err)
+			assert.Equal(t, cp, got, "expected checkpoint %v, got %v", cp, got)
+		})
+		t.Run("conflicting options", func(t *testing.T) {
+			cp := options.ChangeStreamCheckpoint{OperationTime: &primitive.Timestamp{T: 10, I: 1}}
+			opts := options.ChangeStream().SetStartFromCheckpoint(cp).SetStartAtOperationTime(&primitive.Timestamp{T: 1})
+			_, err := setupColl("foo").Watch(bgCtx, Pipeline{}, opts)
+			assert.NotNil(t, err, "expected error, got nil")
+			assert.True(t, strings.Contains(err.Error(), "StartFromCheckpoint"), "expected StartFromCheckpoint error, got %v", err)
+		})
+	})
+	t.Run("validate pipeline options", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			opts     *options.ChangeStreamOptions
+			max      int32
+			expected error
+		}{
+			{"no options", options.ChangeStream(), 6, nil},
+			{"start at operation time", options.ChangeStream().SetStartAtOperationTime(&primitive.Timestamp{T: 1}), 6,
+				UnsupportedChangeStreamOptionError{Option: "startAtOperationTime", MinWireVersion: 7, Have: 6}},
+			{"start after", options.ChangeStream().SetStartAfter(bson.D{{"_data", "1"}}), 7,
+				UnsupportedChangeStreamOptionError{Option: "startAfter", MinWireVersion: 8, Have: 7}},
+			{"show expanded events", options.ChangeStream().SetShowExpandedEvents(false), 13,
+				UnsupportedChangeStreamOptionError{Option: "showExpandedEvents", MinWireVersion: 17, Have: 13}},
+			{"show system events", options.ChangeStream().SetShowSystemEvents(true), 13,
+				UnsupportedChangeStreamOptionError{Option: "showSystemEvents", MinWireVersion: 17, Have: 13}},
+			{"supported", options.ChangeStream().SetShowExpandedEvents(true), 17, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := validatePipelineOptions(tc.opts, &description.VersionRange{Min: 0, Max: tc.max})
+				assert.Equal(t, tc.expected, err, "expected error %v, got %v", tc.expected, err)
+			})
+		}
+
+		err := validatePipelineOptions(options.ChangeStream().SetShowExpandedEvents(true), nil)
+		assert.Nil(t, err, "expected no error without a wire version, got %v", err)
+	})
+	t.Run("validate pipeline options on resume", func(t *testing.T) {
+		deployment := newCommandDeployment(17,
+			newCursorReply(1, "firstBatch", newTestEvent("1")),
+			bson.D{{"ok", 0}, {"code", errorCursorNotFound}, {"errmsg", "cursor not found"}},
+			bson.D{{"ok", 1}},
+			newCursorReply(1, "firstBatch", newTestEvent("2")))
+		opts := options.ChangeStream().SetStartAfter(bson.D{{"_data", "0"}})
+		cs, err := newCommandChangeStream(t, deployment, opts)
+		assert.Nil(t, err, "newChangeStream error: %v", err)
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+		// The resume replaces startAfter with resumeAfter, so it succeeds on a server that does not support startAfter.
+		deployment.conn.Desc.WireVersion = &description.VersionRange{Min: 6, Max: 7}
+		assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+	})
+	t.Run("context error", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(bgCtx)
+		cancel()
+
+		t.Run("resumes with new context", func(t *testing.T) {
+			deployment := newCommandDeployment(17,
+				newCursorReply(1, "firstBatch", newTestEvent("1")),
+				bson.D{{"ok", 1}},
+				newCursorReply(1, "firstBatch", newTestEvent("2")))
+			cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			assert.False(t, cs.Next(cancelledCtx), "expected Next to return false, got true")
+			err = cs.Err()
+			assert.True(t, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+			_ = deployment.commands(t)
+
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+			data := cs.ResumeToken().Lookup("_data").StringValue()
+			assert.Equal(t, "2", data, "expected resume token for event 2, got %v", cs.ResumeToken())
+
+			// The cursor is closed and the change stream resumes after the last event that was returned.
+			cmds := deployment.commands(t)
+			assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+			name := cmds[0].Index(0).Key()
+			assert.Equal(t, "killCursors", name, "expected killCursors command, got %v", name)
+			data = changeStreamStage(cmds[1]).Lookup("resumeAfter", "_data").StringValue()
+			assert.Equal(t, "1", data, "expected resumeAfter for event 1, got %v", data)
+		})
+		t.Run("server error", func(t *testing.T) {
+			cursor := newTestChangeStreamCursor(t, []bson.D{newTestEvent("1")})
+			cursor.err = CommandError{Code: 13, Name: "Unauthorized"}
+			cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+			// An error that was not caused by the context is not cleared by the next call.
+			assert.False(t, cs.Next(cancelledCtx), "expected Next to return false, got true")
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+			err := cs.Err()
+			assert.Equal(t, cursor.err, err, "expected error %v, got %v", cursor.err, err)
+		})
+		t.Run("failed resume", func(t *testing.T) {
+			deployment := newCommandDeployment(17,
+				newCursorReply(1, "firstBatch", newTestEvent("1")),
+				newCursorReply(1, "firstBatch", newTestEvent("2")))
+			cs, err := newCommandChangeStream(t, deployment, options.ChangeStream())
+			assert.Nil(t, err, "newChangeStream error: %v", err)
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+
+			// A resume that fails because its context expired is retried by the next call.
+			assert.False(t, cs.Next(cancelledCtx), "expected Next to return false, got true")
+			assert.False(t, cs.Next(cancelledCtx), "expected Next to return false, got true")
+			err = cs.Err()
+			assert.True(t, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			data := cs.ResumeToken().Lookup("_data").StringValue()
+			assert.Equal(t, "2", data, "expected resume token for event 2, got %v", cs.ResumeToken())
+		})
+	})
+	t.Run("pipeline size", func(t *testing.T) {
+		cs := &ChangeStream{options: options.ChangeStream(), registry: bson.DefaultRegistry}
+		largeStage := bson.D{{"$match", bson.D{{"x", strings.Repeat("a", 1000)}}}}
+		err := cs.buildPipelineSlice(bson.A{largeStage})
+		assert.Nil(t, err, "buildPipelineSlice error: %v", err)
+
+		err = cs.validatePipelineSize(description.Server{MaxDocumentSize: 16 * 1024 * 1024})
+		assert.Nil(t, err, "validatePipelineSize error: %v", err)
+		err = cs.validatePipelineSize(description.Server{})
+		assert.Nil(t, err, "validatePipelineSize error: %v", err)
+
+		err = cs.validatePipelineSize(description.Server{Addr: "localhost:27017", MaxDocumentSize: 512})
+		assert.True(t, errors.Is(err, ErrPipelineTooLarge), "expected error %v, got %v", ErrPipelineTooLarge, err)
+		assert.True(t, strings.Contains(err.Error(), "512 bytes"), "expected error to contain the limit, got %v", err)
+	})
+	t.Run("start after operation", func(t *testing.T) {
+		pin := &primitive.Timestamp{T: 100, I: 1}
+		earlier := &primitive.Timestamp{T: 50, I: 1}
+
+		t.Run("resume does not go before pinned time", func(t *testing.T) {
+			opts := options.MergeChangeStreamOptions(options.ChangeStream().SetStartAfterOperation(pin))
+			cs := &ChangeStream{
+				options:       opts,
+				sess:          &session.Client{OperationTime: earlier},
+				operationTime: earlier,
+			}
+			cs.replaceOptions(&description.VersionRange{Min: 0, Max: 8})
+			got := cs.options.StartAtOperationTime
+			assert.Equal(t, pin, got, "expected startAtOperationTime %v, got %v", pin, got)
+		})
+		t.Run("resume after pinned time", func(t *testing.T) {
+			later := &primitive.Timestamp{T: 150, I: 1}
+			opts := options.MergeChangeStreamOptions(options.ChangeStream().SetStartAfterOperation(pin))
+			cs := &ChangeStream{options: opts, sess: &session.Client{OperationTime: later}, operationTime: later}
+			cs.replaceOptions(&description.VersionRange{Min: 0, Max: 8})
+			got := cs.options.StartAtOperationTime
+			assert.Equal(t, later, got, "expected startAtOperationTime %v, got %v", later, got)
+		})
+		t.Run("conflicting options", func(t *testing.T) {
+			opts := options.ChangeStream().SetStartAfterOperation(pin).SetResumeAfter(bson.D{{"_data", "1"}})
+			_, err := setupColl("foo").Watch(bgCtx, Pipeline{}, opts)
+			assert.NotNil(t, err, "expected error, got nil")
+		})
+	})
+	t.Run("max backfill duration", func(t *testing.T) {
+		openedAt := time.Now()
+		oldEvent := func(data string) bson.D {
+			return newTestEvent(data, bson.E{"clusterTime", primitive.Timestamp{T: 1, I: 1}})
+		}
+		newEvent := func(data string) bson.D {
+			later := uint32(openedAt.Add(time.Minute).Unix())
+			return newTestEvent(data, bson.E{"clusterTime", primitive.Timestamp{T: later, I: 1}})
+		}
+		newBackfillChangeStream := func(cursor changeStreamCursor, clock *time.Time) *ChangeStream {
+			opts := options.ChangeStream().SetMaxBackfillDuration(time.Second)
+			return &ChangeStream{
+				cursor:   cursor,
+				options:  options.MergeChangeStreamOptions(opts),
+				openedAt: openedAt,
+				now:      func() time.Time { return *clock },
+			}
+		}
+
+		t.Run("too slow", func(t *testing.T) {
+			clock := openedAt
+			cs := newBackfillChangeStream(newTestChangeStreamCursor(t, []bson.D{oldEvent("1"), oldEvent("2")}), &clock)
+
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			clock = clock.Add(2 * time.Second)
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
+			assert.Equal(t, ErrBackfillTooSlow, cs.Err(), "expected error %v, got %v", ErrBackfillTooSlow, cs.Err())
+		})
+		t.Run("within duration", func(t *testing.T) {
+			clock := openedAt
+			cs := newBackfillChangeStream(newTestChangeStreamCursor(t, []bson.D{oldEvent("1"), oldEvent("2")}), &clock)
+
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			clock = clock.Add(time.Second)
+			assert.True(t, cs.Next(bgCtx), "expected Next to return true, got false")
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		})
+		t.Run("caught up", func(t *testing.T) {
+			clock := openedAt
+			cursor := newTestChangeStreamCursor(t, []bson.D{oldEvent("1"), newEvent("2"), oldEvent("3")})
+			cs := newBackfillChangeStream(cursor, &clock)
+
+			for i := 0; i < 3; i++ {
+				assert.True(t, cs.Next(bgCtx), "expected Next to return true for event %d, got false", i)
+				clock = clock.Add(2 * time.Second)
+			}
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		})
+	})
+	t.Run("validate full document", func(t *testing.T) {
+		wv36 := &description.VersionRange{Min: 0, Max: 6}
+		wv60 := &description.VersionRange{Min: 0, Max: 17}
+		testCases := []struct {
+			name        string
+			opts        *options.ChangeStreamOptions
+			wireVersion *description.VersionRange
+			wantErr     bool
+		}{
+			{"default", options.ChangeStream(), wv36, false},
+			{"update lookup", options.ChangeStream().SetFullDocument(options.UpdateLookup), wv36, false},
+			{"required on old server", options.ChangeStream().SetFullDocument(options.Required), wv36, true},
+			{"required", options.ChangeStream().SetFullDocument(options.Required), wv60, false},
+			{"before change on old server",
+				options.ChangeStream().SetFullDocumentBeforeChange(options.WhenAvailable), wv36, true},
+			{"before change off on old server",
+				options.ChangeStream().SetFullDocumentBeforeChange(options.Off), wv36, false},
+			{"unknown wire version", options.ChangeStream().SetFullDocument(options.Required), nil, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := validateFullDocument(tc.opts, tc.wireVersion)
+				if !tc.wantErr {
+					assert.Nil(t, err, "validateFullDocument error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, ErrFullDocumentNotSupported),
+					"expected error %v, got %v", ErrFullDocumentNotSupported, err)
+			})
+		}
+	})
+}
+
+// BenchmarkChangeStreamNext measures the per-event cost of iterating a change stream without decoding events, which
+// is the common path for consumers that only inspect Current.
+func BenchmarkChangeStreamNext(b *testing.B) {
+	const batchSize = 100
+
+	batch := make([]bson.D, 0, batchSize)
+	for i := 0; i < batchSize; i++ {
+		batch = append(batch, newTestEvent("token", bson.E{"operationType", "insert"},
+			bson.E{"fullDocument", bson.D{{"x", int32(i)}}}))
+	}
+	// The batches use ArrayStyle to match the batches returned by driver.BatchCursor.
+	idx, arr := bsoncore.AppendArrayStart(nil)
+	for i, doc := range batch {
+		raw, err := bson.Marshal(doc)
+		assert.Nil(b, err, "Marshal error: %v", err)
+		arr = bsoncore.AppendDocumentElement(arr, strconv.Itoa(i), raw)
+	}
+	arr, err := bsoncore.AppendArrayEnd(arr, idx)
+	assert.Nil(b, err, "AppendArrayEnd error: %v", err)
+	cursor := &testChangeStreamCursor{}
+	for i := 0; i < b.N/batchSize+1; i++ {
+		cursor.batches = append(cursor.batches, &bsoncore.DocumentSequence{Style: bsoncore.ArrayStyle, Data: arr})
+	}
+	cs := &ChangeStream{cursor: cursor, options: options.ChangeStream()}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !cs.Next(bgCtx) {
+			b.Fatalf("expected Next to return true, got false: %v", cs.Err())
+		}
+	}
 }
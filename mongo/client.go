@@ -233,8 +233,26 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 //
 // Connect starts background goroutines to monitor the state of the deployment and does not do any I/O in the main
 // goroutine. The Client.Ping method can be used to verify that the connection was created successfully.
+//
+// If ctx has already expired, Connect does not start any background goroutines and returns an error that matches both
+// ErrConnectCancelled and the context error with errors.Is. Otherwise, the connection attempts that the connection
+// pools make to reach the size required by the MinPoolSize option are cancelled if ctx expires before they complete.
+// The background goroutines themselves are tied to the lifetime of the Client rather than to ctx, so once ctx has
+// expired the pools continue to maintain MinPoolSize connections until Disconnect is called.
 func (c *Client) Connect(ctx context.Context) error {
-	if connector, ok := c.deployment.(driver.Connector); ok {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return wrappedSentinelError{sentinel: ErrConnectCancelled, err: err}
+	}
+
+	if connector, ok := c.deployment.(driver.ContextConnector); ok {
+		err := connector.ConnectContext(ctx)
+		if err != nil {
+			return replaceErrors(err)
+		}
+	} else if connector, ok := c.deployment.(driver.Connector); ok {
 		err := connector.Connect()
 		if err != nil {
 			return replaceErrors(err)
@@ -360,6 +378,56 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	return replaceErrors(res.Err())
 }
 
+// CheckCompatibility verifies that every server in the deployment supports the minimum wire version required by the
+// driver. It sends a ping command to wait for the deployment to be discovered and then inspects the wire versions
+// reported by each server. If a server is older than the minimum supported version, an ErrServerTooOld is returned.
+// Otherwise, any error from the ping command is returned.
+//
+// This can be used in application health checks to report incompatible servers with a clear error rather than the
+// server selection errors returned by other operations.
+func (c *Client) CheckCompatibility(ctx context.Context) error {
+	pingErr := c.Ping(ctx, nil)
+
+	if t, ok := c.deployment.(*topology.Topology); ok {
+		if err := checkServerVersions(t.Description()); err != nil {
+			return err
+		}
+	}
+	return pingErr
+}
+
+// legacyServerVersions maps the maximum wire versions of servers that are no longer supported by the driver to the
+// server versions that report them.
+var legacyServerVersions = map[int32]string{
+	0: "2.4",
+	1: "2.6",
+	2: "2.6",
+	3: "3.0",
+	4: "3.2",
+	5: "3.4",
+}
+
+// checkServerVersions returns an ErrServerTooOld if any server in desc reports a maximum wire version lower than the
+// minimum supported by the driver.
+func checkServerVersions(desc description.Topology) error {
+	for _, server := range desc.Servers {
+		if server.WireVersion == nil || server.WireVersion.Max >= topology.SupportedWireVersions.Min {
+			continue
+		}
+
+		actual, ok := legacyServerVersions[server.WireVersion.Max]
+		if !ok {
+			actual = "unknown"
+		}
+		return ErrServerTooOld{
+			Address:       server.Addr.String(),
+			MinVersion:    topology.MinSupportedMongoDBVersion,
+			ActualVersion: actual,
+		}
+	}
+	return nil
+}
+
 // StartSession starts a new session configured with the given options.
 //
 // StartSession does not actually communicate with the server and will not error if the client is
@@ -738,20 +806,27 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 	return names, nil
 }
 
-// WithSession creates a new SessionContext from the ctx and sess parameters and uses it to call the fn callback. The
-// SessionContext must be used as the Context parameter for any operations in the fn callback that should be executed
-// under the session.
+// WithSessionContext creates a new SessionContext from the ctx and sess parameters and uses it to call the fn
+// callback. The SessionContext must be used as the Context parameter for any operations in the fn callback that should
+// be executed under the session.
 //
-// WithSession is safe to call from multiple goroutines concurrently. However, the SessionContext passed to the
-// WithSession callback function is not safe for concurrent use by multiple goroutines.
+// WithSessionContext is safe to call from multiple goroutines concurrently. However, the SessionContext passed to the
+// WithSessionContext callback function is not safe for concurrent use by multiple goroutines.
 //
 // If the ctx parameter already contains a Session, that Session will be replaced with the one provided.
 //
 // Any error returned by the fn callback will be returned without any modifications.
-func WithSession(ctx context.Context, sess Session, fn func(SessionContext) error) error {
+func WithSessionContext(ctx context.Context, sess Session, fn func(SessionContext) error) error {
 	return fn(NewSessionContext(ctx, sess))
 }
 
+// WithSession creates a new SessionContext from the ctx and sess parameters and uses it to call the fn callback.
+//
+// Deprecated: Use WithSessionContext instead.
+func WithSession(ctx context.Context, sess Session, fn func(SessionContext) error) error {
+	return WithSessionContext(ctx, sess, fn)
+}
+
 // UseSession creates a new Session and uses it to create a new SessionContext, which is used to call the fn callback.
 // The SessionContext parameter must be used as the Context parameter for any operations in the fn callback that should
 // be executed under a session. After the callback returns, the created Session is ended, meaning that any in-progress
@@ -14,6 +14,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -219,6 +220,25 @@ func (ce *ClientEncryption) Encrypt(ctx context.Context, val bson.RawValue,
 	return primitive.Binary{Subtype: subtype, Data: data}, nil
 }
 
+// EncryptMany encrypts each of the given BSON values with the same key and algorithm and returns the encrypted values
+// (BSON binary values of subtype 6) in the same order. It is a convenience for calling Encrypt on each value in turn:
+// the values are encrypted one at a time and no requests to the key vault or KMS provider are batched. If encrypting
+// a value fails, the returned error includes its index and no values are returned.
+func (ce *ClientEncryption) EncryptMany(ctx context.Context, vals []bson.RawValue,
+	opts ...*options.EncryptOptions) ([]bson.RawValue, error) {
+
+	transformed := transformExplicitEncryptionOptions(opts...)
+	encrypted := make([]bson.RawValue, 0, len(vals))
+	for i, val := range vals {
+		subtype, data, err := ce.crypt.EncryptExplicit(ctx, bsoncore.Value{Type: val.Type, Data: val.Value}, transformed)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting value at index %d: %w", i, err)
+		}
+		encrypted = append(encrypted, bson.RawValue{Type: bsontype.Binary, Value: bsoncore.AppendBinary(nil, subtype, data)})
+	}
+	return encrypted, nil
+}
+
 // EncryptExpression encrypts an expression to query a range index.
 // On success, `result` is populated with the resulting BSON document.
 // `expr` is expected to be a BSON document of one of the following forms:
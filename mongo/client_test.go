@@ -18,6 +18,8 @@ import (
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/testutil"
+	"go.mongodb.org/mongo-driver/mongo/address"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -51,6 +53,17 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, dbName, db.Name(), "expected db name %v, got %v", dbName, db.Name())
 		assert.Equal(t, client, db.Client(), "expected client %v, got %v", client, db.Client())
 	})
+	t.Run("connect cancelled", func(t *testing.T) {
+		client := setupClient()
+		ctx, cancel := context.WithCancel(bgCtx)
+		cancel()
+
+		err := client.Connect(ctx)
+		assert.True(t, errors.Is(err, ErrConnectCancelled), "expected error %v, got %v", ErrConnectCancelled, err)
+		assert.True(t, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+		_, err = client.StartSession()
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
 	t.Run("replace topology error", func(t *testing.T) {
 		client := setupClient()
 
@@ -494,4 +507,31 @@ func TestClient(t *testing.T) {
 			})
 		}
 	})
+	t.Run("check server versions", func(t *testing.T) {
+		newServer := func(addr string, maxWireVersion int32) description.Server {
+			return description.Server{
+				Addr:        address.Address(addr),
+				WireVersion: &description.VersionRange{Min: 0, Max: maxWireVersion},
+			}
+		}
+		testCases := []struct {
+			name    string
+			servers []description.Server
+			err     error
+		}{
+			{"supported", []description.Server{newServer("a:27017", 6), newServer("b:27017", 17)}, nil},
+			{"unknown wire version", []description.Server{{Addr: "a:27017"}}, nil},
+			{
+				"too old",
+				[]description.Server{newServer("a:27017", 17), newServer("b:27017", 5)},
+				ErrServerTooOld{Address: "b:27017", MinVersion: "3.6", ActualVersion: "3.4"},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := checkServerVersions(description.Topology{Servers: tc.servers})
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
 }
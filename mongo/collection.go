@@ -10,12 +10,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -167,6 +169,11 @@ func (coll *Collection) Database() *Database {
 	return coll.db
 }
 
+// Namespace returns the namespace of the collection in the form "database.collection".
+func (coll *Collection) Namespace() string {
+	return coll.db.name + "." + coll.name
+}
+
 // BulkWrite performs a bulk write operation (https://www.mongodb.com/docs/manual/core/bulk-write-operations/).
 //
 // The models parameter must be a slice of operations to be executed in this bulk write. It cannot be nil or empty.
@@ -235,7 +242,91 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 	return &op.result, replaceErrors(err)
 }
 
-func (coll *Collection) insert(ctx context.Context, documents []interface{},
+// BulkWriteWithResult performs a bulk write operation and returns the result of each write model individually. Because
+// the server only reports aggregate counts for each command, every model is sent to the server in a separate command,
+// so this method requires one round trip per model. Use BulkWrite if only aggregate counts are needed.
+//
+// The models parameter must be a slice of operations to be executed in this bulk write. It cannot be nil or empty.
+// All of the models must be non-nil. See the mongo.WriteModel documentation for a list of valid model types and
+// examples of how they should be used.
+//
+// If the bulk write is ordered, execution stops at the first model that fails. Otherwise, every model is executed and
+// the error for each failed model is available in the Error field of its WriteModelResult. In both cases, the returned
+// error is a BulkWriteException containing the write errors of all failed models, with each BulkWriteError's Index set
+// to the index of the model in the models parameter. If an error other than a write error occurs, execution stops and
+// that error is returned along with the results of the models executed so far.
+//
+// The opts parameter can be used to specify options for the operation (see the options.BulkWriteOptions documentation.)
+func (coll *Collection) BulkWriteWithResult(ctx context.Context, models []WriteModel,
+	opts ...*options.BulkWriteOptions) (*BulkWriteDetailedResult, error) {
+
+	if len(models) == 0 {
+		return nil, ErrEmptySlice
+	}
+	for _, model := range models {
+		if model == nil {
+			return nil, ErrNilDocument
+		}
+	}
+
+	bwo := options.MergeBulkWriteOptions(opts...)
+	ordered := bwo.Ordered == nil || *bwo.Ordered
+
+	result := &BulkWriteDetailedResult{
+		Results: make([]WriteModelResult, 0, len(models)),
+	}
+	bwErr := BulkWriteException{
+		WriteErrors: make([]BulkWriteError, 0),
+	}
+	var unacknowledged bool
+	for i, model := range models {
+		res, err := coll.BulkWrite(ctx, []WriteModel{model}, bwo)
+
+		var modelRes WriteModelResult
+		if res != nil {
+			modelRes.InsertedCount = res.InsertedCount
+			modelRes.MatchedCount = res.MatchedCount
+			modelRes.ModifiedCount = res.ModifiedCount
+			modelRes.DeletedCount = res.DeletedCount
+			modelRes.UpsertedID = res.UpsertedIDs[0]
+		}
+
+		switch modelErr := err.(type) {
+		case nil:
+		case BulkWriteException:
+			for j := range modelErr.WriteErrors {
+				modelErr.WriteErrors[j].Index = i
+			}
+			modelRes.Error = modelErr
+
+			bwErr.WriteErrors = append(bwErr.WriteErrors, modelErr.WriteErrors...)
+			if modelErr.WriteConcernError != nil {
+				bwErr.WriteConcernError = modelErr.WriteConcernError
+			}
+			bwErr.Labels = append(bwErr.Labels, modelErr.Labels...)
+		default:
+			if err != ErrUnacknowledgedWrite {
+				return result, err
+			}
+			unacknowledged = true
+		}
+
+		result.Results = append(result.Results, modelRes)
+		if ordered && modelRes.Error != nil {
+			break
+		}
+	}
+
+	if len(bwErr.WriteErrors) > 0 || bwErr.WriteConcernError != nil {
+		return result, bwErr
+	}
+	if unacknowledged {
+		return result, ErrUnacknowledgedWrite
+	}
+	return result, nil
+}
+
+func (coll *Collection) insert(ctx context.Context, documents []interface{}, progressFn func(inserted int),
 	opts ...*options.InsertManyOptions) ([]interface{}, error) {
 
 	if ctx == nil {
@@ -303,6 +394,11 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		retry = driver.RetryOncePerCommand
 	}
 	op = op.Retry(retry)
+	if progressFn != nil {
+		op = op.ProgressFn(func(inserted int64) {
+			progressFn(int(inserted))
+		})
+	}
 
 	err = op.Execute(ctx)
 	wce, ok := err.(driver.WriteCommandError)
@@ -346,7 +442,7 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	if ioOpts.Comment != nil {
 		imOpts.SetComment(ioOpts.Comment)
 	}
-	res, err := coll.insert(ctx, []interface{}{document}, imOpts)
+	res, err := coll.insert(ctx, []interface{}{document}, nil, imOpts)
 
 	rr, err := processWriteError(err)
 	if rr&rrOne == 0 {
@@ -355,6 +451,60 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	return &InsertOneResult{InsertedID: res[0]}, err
 }
 
+// InsertOrFind inserts a document into the collection if no document with the same _id exists, and returns the
+// document stored in the collection in either case. It executes a findAndModify command that filters on the _id of the
+// document and sets the document's fields using $setOnInsert with upsert set to true, so the insert and lookup happen
+// atomically.
+//
+// The document parameter must be the document to be inserted. It cannot be nil. If the document does not have an _id
+// field when transformed into BSON, one will be added automatically to the marshalled document, which means that a new
+// document will always be inserted.
+//
+// The returned inserted value is true if the document was inserted and false if an existing document was found. The
+// returned SingleResult contains the document as it is stored in the collection.
+//
+// The opts parameter can be used to specify options for the operation (see the options.InsertOrFindOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/findAndModify/.
+func (coll *Collection) InsertOrFind(ctx context.Context, document interface{},
+	opts ...*options.InsertOrFindOptions) (bool, *SingleResult, error) {
+
+	doc, _, err := transformAndEnsureID(coll.registry, document)
+	if err != nil {
+		return false, nil, err
+	}
+	filter := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendValueElement(nil, "_id", doc.Lookup("_id")))
+	update := bsoncore.NewDocumentBuilder().AppendDocument("$setOnInsert", doc).Build()
+
+	iofo := options.MergeInsertOrFindOptions(opts...)
+	op := operation.NewFindAndModify(filter).Update(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: update}).
+		Upsert(true).NewDocument(true).ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout)
+	if iofo.BypassDocumentValidation != nil && *iofo.BypassDocumentValidation {
+		op = op.BypassDocumentValidation(*iofo.BypassDocumentValidation)
+	}
+	if iofo.Comment != nil {
+		comment, err := transformValue(coll.registry, iofo.Comment, true, "comment")
+		if err != nil {
+			return false, nil, err
+		}
+		op = op.Comment(comment)
+	}
+	if iofo.Projection != nil {
+		proj, err := transformBsoncoreDocument(coll.registry, iofo.Projection, true, "projection")
+		if err != nil {
+			return false, nil, err
+		}
+		op = op.Fields(proj)
+	}
+
+	res := coll.findAndModify(ctx, op)
+	if res.err != nil {
+		return false, nil, res.err
+	}
+	return !op.Result().LastErrorObject.UpdatedExisting, res, nil
+}
+
 // InsertMany executes an insert command to insert multiple documents into the collection. If write errors occur
 // during the operation (e.g. duplicate key error), this method returns a BulkWriteException error.
 //
@@ -369,11 +519,60 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
 
+	return coll.insertMany(ctx, documents, nil, opts...)
+}
+
+// InsertManyWithProgress executes an insert command to insert multiple documents into the collection and reports
+// progress while doing so. The driver splits large inserts into multiple insert commands based on the server's
+// limits. After each of those commands completes, progressFn is called with the total number of
+// documents inserted so far. The progressFn parameter may be nil, in which case this is equivalent to InsertMany.
+//
+// See InsertMany for a description of the documents and opts parameters and of the returned values.
+func (coll *Collection) InsertManyWithProgress(ctx context.Context, documents []interface{},
+	progressFn func(inserted int), opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
+
+	return coll.insertMany(ctx, documents, progressFn, opts...)
+}
+
+// InsertManyWithIDs executes an insert command to insert multiple documents into the collection. Unlike InsertMany, an
+// ObjectID _id is assigned to each document that does not have one before any command is sent to the server, and the
+// _id values of all documents are returned even if the insert fails. InsertManyWithIDs still blocks until the insert
+// completes, but because the IDs do not depend on the result, callers can record them (e.g. in local state) and
+// reconcile them with the returned error, including documents that were not inserted.
+//
+// The returned IDs are in the same order as documents. If a document cannot be marshalled, no IDs are returned and no
+// documents are inserted. See InsertMany for a description of the documents and opts parameters and of the returned
+// error.
+func (coll *Collection) InsertManyWithIDs(ctx context.Context, documents []interface{},
+	opts ...*options.InsertManyOptions) ([]interface{}, error) {
+
 	if len(documents) == 0 {
 		return nil, ErrEmptySlice
 	}
 
-	result, err := coll.insert(ctx, documents, opts...)
+	ids := make([]interface{}, 0, len(documents))
+	docs := make([]interface{}, 0, len(documents))
+	for _, doc := range documents {
+		bsonDoc, id, err := transformAndEnsureID(coll.registry, doc)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		docs = append(docs, bson.Raw(bsonDoc))
+	}
+
+	_, err := coll.insertMany(ctx, docs, nil, opts...)
+	return ids, err
+}
+
+func (coll *Collection) insertMany(ctx context.Context, documents []interface{}, progressFn func(inserted int),
+	opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
+
+	if len(documents) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	result, err := coll.insert(ctx, documents, progressFn, opts...)
 	rr, err := processWriteError(err)
 	if rr&rrMany == 0 {
 		return nil, err
@@ -528,18 +727,21 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 }
 
 func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Document, update interface{}, multi bool,
-	expectedRr returnResult, checkDollarKey bool, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	expectedRr returnResult, checkDocument func(bsoncore.Document) error, opts ...*options.UpdateOptions) (*UpdateResult, error) {
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	uo := options.MergeUpdateOptions(opts...)
+	if err := uo.Validate(); err != nil {
+		return nil, err
+	}
 
 	// collation, arrayFilters, upsert, and hint are included on the individual update documents rather than as part of the
 	// command
 	updateDoc, err := createUpdateDoc(filter, update, uo.Hint, uo.ArrayFilters, uo.Collation, uo.Upsert, multi,
-		checkDollarKey, coll.registry)
+		checkDocument, coll.registry)
 	if err != nil {
 		return nil, err
 	}
@@ -631,6 +833,9 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 // (https://www.mongodb.com/docs/manual/reference/operator/update/) and can be used to specify the modifications to be
 // made to the selected document. It cannot be nil or empty.
 //
+// If the ReplaceDoc option is true and the update parameter is a document whose keys do not begin with '$', the
+// document is used as a replacement instead, as with ReplaceOne.
+//
 // The opts parameter can be used to specify options for the operation (see the options.UpdateOptions documentation).
 //
 // For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/update/.
@@ -639,7 +844,28 @@ func (coll *Collection) UpdateByID(ctx context.Context, id interface{}, update i
 	if id == nil {
 		return nil, ErrNilValue
 	}
-	return coll.UpdateOne(ctx, bson.D{{"_id", id}}, update, opts...)
+
+	uo := options.MergeUpdateOptions(opts...)
+	if uo.ReplaceDoc == nil || !*uo.ReplaceDoc || update == nil {
+		return coll.UpdateOne(ctx, bson.D{{"_id", id}}, update, opts...)
+	}
+
+	// Pipelines cannot be transformed into a document, so they are always applied as updates.
+	r, err := transformBsoncoreDocument(coll.registry, update, true, "update")
+	if err != nil || ensureNoDollarKey(r) != nil {
+		return coll.UpdateOne(ctx, bson.D{{"_id", id}}, update, opts...)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	f, err := transformBsoncoreDocument(coll.registry, bson.D{{"_id", id}}, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.updateOrReplace(ctx, f, r, false, rrOne, ensureNoDollarKey, opts...)
 }
 
 // UpdateOne executes an update command to update at most one document in the collection.
@@ -668,7 +894,41 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 		return nil, err
 	}
 
-	return coll.updateOrReplace(ctx, f, update, false, rrOne, true, opts...)
+	return coll.updateOrReplace(ctx, f, update, false, rrOne, ensureDollarKey, opts...)
+}
+
+// UpdateOnePipeline executes an update command to update at most one document in the collection using an update
+// pipeline. This is equivalent to calling UpdateOne with a pipeline, but the pipeline is checked before the command is
+// sent.
+//
+// The filter parameter must be a document containing query operators and can be used to select the document to be
+// updated. It cannot be nil. If the filter does not match any documents, the operation will succeed and an UpdateResult
+// with a MatchedCount of 0 will be returned. If the filter matches multiple documents, one will be selected from the
+// matched set and MatchedCount will equal 1.
+//
+// The pipeline parameter must contain at least one stage, and each stage must be one of $addFields, $set, $project,
+// $unset, $replaceRoot, or $replaceWith. If this is not the case, an error wrapping ErrInvalidUpdatePipelineStage is
+// returned. Update pipelines are only supported by MongoDB versions >= 4.2.
+//
+// The opts parameter can be used to specify options for the operation (see the options.UpdateOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/update/.
+func (coll *Collection) UpdateOnePipeline(ctx context.Context, filter interface{}, pipeline Pipeline,
+	opts ...*options.UpdateOptions) (*UpdateResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := validateUpdatePipeline(pipeline); err != nil {
+		return nil, err
+	}
+	f, err := transformBsoncoreDocument(coll.registry, filter, true, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.updateOrReplace(ctx, f, pipeline, false, rrOne, nil, opts...)
 }
 
 // UpdateMany executes an update command to update documents in the collection.
@@ -696,7 +956,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		return nil, err
 	}
 
-	return coll.updateOrReplace(ctx, f, update, true, rrMany, true, opts...)
+	return coll.updateOrReplace(ctx, f, update, true, rrMany, ensureDollarKey, opts...)
 }
 
 // ReplaceOne executes an update command to replace at most one document in the collection.
@@ -729,8 +989,9 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		return nil, err
 	}
 
-	if err := ensureNoDollarKey(r); err != nil {
-		return nil, err
+	checkDocument := ensureNoDollarKey
+	if ro := options.MergeReplaceOptions(opts...); ro.SkipOperatorCheck != nil && *ro.SkipOperatorCheck {
+		checkDocument = nil
 	}
 
 	updateOptions := make([]*options.UpdateOptions, 0, len(opts))
@@ -748,7 +1009,7 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		updateOptions = append(updateOptions, uOpts)
 	}
 
-	return coll.updateOrReplace(ctx, f, r, false, rrOne, false, updateOptions...)
+	return coll.updateOrReplace(ctx, f, r, false, rrOne, checkDocument, updateOptions...)
 }
 
 // Aggregate executes an aggregate command against the collection and returns a cursor over the resulting documents.
@@ -915,7 +1176,11 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
 			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
 		}
-		return nil, replaceErrors(err)
+		err = replaceErrors(err)
+		if ave, ok := AsAggregationValidationError(err); ok && hasOutputStage {
+			return nil, ave
+		}
+		return nil, err
 	}
 
 	bc, err := op.Result(cursorOpts)
@@ -1012,6 +1277,26 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	return val, nil
 }
 
+// CountByField returns the number of documents in the collection whose fieldName field is equal to value. This is
+// equivalent to running CountDocuments(ctx, bson.D{{fieldName, bson.D{{"$eq", value}}}}, opts...). The $eq operator
+// is used so that a document value is always compared for equality and never interpreted as query operators.
+//
+// The fieldName parameter can use dot notation to refer to a field of an embedded document. It cannot be empty or
+// begin with '$', because such a name would be interpreted as a query operator rather than a field.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CountOptions documentation).
+func (coll *Collection) CountByField(ctx context.Context, fieldName string, value interface{},
+	opts ...*options.CountOptions) (int64, error) {
+
+	if fieldName == "" {
+		return 0, errors.New("fieldName cannot be empty")
+	}
+	if strings.HasPrefix(fieldName, "$") {
+		return 0, fmt.Errorf("fieldName cannot begin with '$', got %q", fieldName)
+	}
+	return coll.CountDocuments(ctx, bson.D{{fieldName, bson.D{{"$eq", value}}}}, opts...)
+}
+
 // EstimatedDocumentCount executes a count command and returns an estimate of the number of documents in the collection
 // using collection metadata.
 //
@@ -1074,6 +1359,40 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	return op.Result().N, replaceErrors(err)
 }
 
+// CountDocumentsEstimated returns the number of documents in the collection, choosing the cheapest way to compute it.
+// If filter is nil and no skip or limit is specified, the count is computed from collection metadata using
+// EstimatedDocumentCount. Otherwise, an exact count is computed using CountDocuments.
+//
+// The maxMs parameter specifies the maximum amount of time, in milliseconds, that the count is allowed to run on the
+// server, whether it is estimated or exact. If maxMs is 0 or less, no limit is applied beyond any MaxTime specified in
+// opts.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CountOptions documentation).
+// Only the Comment and MaxTime options are used when the count is estimated.
+func (coll *Collection) CountDocumentsEstimated(ctx context.Context, filter interface{}, maxMs int64,
+	opts ...*options.CountOptions) (int64, error) {
+
+	countOpts := options.MergeCountOptions(opts...)
+	if maxMs > 0 {
+		countOpts.SetMaxTime(time.Duration(maxMs) * time.Millisecond)
+	}
+	if filter == nil && countOpts.Skip == nil && countOpts.Limit == nil {
+		eco := options.EstimatedDocumentCount()
+		if countOpts.Comment != nil {
+			eco.SetComment(*countOpts.Comment)
+		}
+		if countOpts.MaxTime != nil {
+			eco.SetMaxTime(*countOpts.MaxTime)
+		}
+		return coll.EstimatedDocumentCount(ctx, eco)
+	}
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+	return coll.CountDocuments(ctx, filter, countOpts)
+}
+
 // Distinct executes a distinct command to find the unique values for a specified field in the collection.
 //
 // The fieldName parameter specifies the field name for which distinct values should be returned.
@@ -1170,6 +1489,65 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	return retArray, replaceErrors(err)
 }
 
+// DistinctWithCollation executes a distinct command using the given collation for string comparisons. It is equivalent
+// to calling Distinct with an options.DistinctOptions whose Collation field is set to collation, which takes precedence
+// over any collation specified in opts.
+//
+// See the Distinct documentation for a description of the fieldName, filter, and opts parameters.
+func (coll *Collection) DistinctWithCollation(ctx context.Context, fieldName string, filter interface{},
+	collation *options.Collation, opts ...*options.DistinctOptions) ([]interface{}, error) {
+
+	opts = append(opts[:len(opts):len(opts)], options.Distinct().SetCollation(collation))
+	return coll.Distinct(ctx, fieldName, filter, opts...)
+}
+
+// DistinctPipeline executes an aggregate command that runs the given pipeline followed by a {$group: {_id: "$<fieldName>"}}
+// stage and returns the distinct values of the field in the documents output by the pipeline. This can be used to find
+// the distinct values of a field that is computed or reshaped by pipeline stages, which Distinct does not support.
+//
+// Unlike Distinct, array values are not expanded into their elements, so an $unwind stage should be included in the
+// pipeline to get the distinct elements of an array field. Documents output by the pipeline that do not have the
+// field contribute a nil value to the result. The order of the returned values is not specified.
+//
+// The pipeline parameter must be a slice of documents, each representing an aggregation stage. It can be nil or empty.
+// The fieldName parameter must not contain a leading "$".
+//
+// The opts parameter can be used to specify options for the aggregate command (see the options.AggregateOptions
+// documentation).
+func (coll *Collection) DistinctPipeline(ctx context.Context, fieldName string, pipeline Pipeline,
+	opts ...*options.AggregateOptions) ([]interface{}, error) {
+
+	if fieldName == "" {
+		return nil, errors.New("DistinctPipeline requires a non-empty field name")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stages := make(Pipeline, 0, len(pipeline)+1)
+	stages = append(stages, pipeline...)
+	stages = append(stages, bson.D{{"$group", bson.D{{"_id", "$" + fieldName}}}})
+
+	cursor, err := coll.Aggregate(ctx, stages, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	values := make([]interface{}, 0)
+	for cursor.Next(ctx) {
+		var value interface{}
+		if err := cursor.Current.Lookup("_id").Unmarshal(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // Find executes a find command and returns a Cursor over the matching documents in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select which documents are
@@ -1346,6 +1724,147 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	return newCursorWithSession(bc, coll.registry, sess)
 }
 
+// TextSearch executes a find command with a $text filter and returns a Cursor over the matching documents in the
+// collection. The collection must have a text index.
+//
+// The text parameter is the string to search for. The language parameter determines the stop words and the rules for
+// the stemmer and tokenizer. If it is empty, the default language of the text index is used.
+//
+// By default, each document is projected to include a "score" field containing its text score. This projection can be
+// replaced by setting the Projection field of an options.FindOptions in opts.
+//
+// See the Find documentation for a description of the opts parameter. For more information about $text queries, see
+// https://www.mongodb.com/docs/manual/reference/operator/query/text/.
+func (coll *Collection) TextSearch(ctx context.Context, text string, language string,
+	opts ...*options.FindOptions) (*Cursor, error) {
+
+	search := bson.D{{"$search", text}}
+	if language != "" {
+		search = append(search, bson.E{"$language", language})
+	}
+	filter := bson.D{{"$text", search}}
+
+	projection := options.Find().SetProjection(bson.D{{"score", bson.D{{"$meta", "textScore"}}}})
+	opts = append([]*options.FindOptions{projection}, opts...)
+	return coll.Find(ctx, filter, opts...)
+}
+
+// PaginatedFind executes a find command and returns a Page of at most pageSize documents from the collection using
+// keyset pagination on the _id field, which is more efficient than skipping documents for large collections. The
+// documents in the collection must have _id values of type primitive.ObjectID.
+//
+// The cursor parameter is the NextCursor of the previous page, or nil to get the first page. Documents are returned in
+// ascending _id order unless sort is {_id: -1}, in which case they are returned in descending _id order. The sort
+// parameter must be nil, {_id: 1}, or {_id: -1}, because documents are paged by their _id.
+//
+// The pageSize parameter must be greater than 0. The Limit and Sort fields of any options.FindOptions in opts are
+// ignored. See the Find documentation for a description of the filter and opts parameters.
+func (coll *Collection) PaginatedFind(ctx context.Context, filter interface{}, pageSize int64,
+	cursor *primitive.ObjectID, sort bson.D, opts ...*options.FindOptions) (*Page, error) {
+
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be greater than 0, got %d", pageSize)
+	}
+	if filter == nil {
+		return nil, ErrNilDocument
+	}
+
+	cmp := "$gt"
+	if len(sort) == 0 {
+		sort = bson.D{{"_id", 1}}
+	} else {
+		var dir int64
+		if len(sort) == 1 && sort[0].Key == "_id" {
+			dir = idSortDirection(sort[0].Value)
+		}
+		switch dir {
+		case 1:
+		case -1:
+			cmp = "$lt"
+		default:
+			return nil, fmt.Errorf("sort must be nil, {_id: 1}, or {_id: -1}, got %v", sort)
+		}
+	}
+
+	if cursor != nil {
+		filter = bson.D{{"$and", bson.A{filter, bson.D{{"_id", bson.D{{cmp, *cursor}}}}}}}
+	}
+
+	// Fetch one extra document to find out whether there is another page.
+	opts = append(opts[:len(opts):len(opts)], options.Find().SetSort(sort).SetLimit(pageSize+1))
+	cur, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []bson.Raw
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	page := &Page{Docs: docs}
+	if int64(len(docs)) > pageSize {
+		page.Docs = docs[:pageSize]
+		page.HasMore = true
+	}
+	if len(page.Docs) > 0 {
+		last := page.Docs[len(page.Docs)-1]
+		id, ok := last.Lookup("_id").ObjectIDOK()
+		if !ok {
+			return nil, fmt.Errorf("PaginatedFind requires _id values of type ObjectID, got %v", last.Lookup("_id"))
+		}
+		page.NextCursor = &id
+	}
+	return page, nil
+}
+
+// idSortDirection returns the sort direction given by v, or 0 if v is neither an integer nor a float64 equal to 1 or
+// -1. A float64 is accepted because the server accepts it and extended JSON decodes numbers as doubles.
+func idSortDirection(v interface{}) int64 {
+	switch dir := v.(type) {
+	case int:
+		return int64(dir)
+	case int32:
+		return int64(dir)
+	case int64:
+		return dir
+	case float64:
+		if dir == 1 || dir == -1 {
+			return int64(dir)
+		}
+	}
+	return 0
+}
+
+// FindAndIterate executes a find command and calls fn with a Cursor over the matching documents in the collection. The
+// cursor is closed when fn returns, even if it returns an error, so it must not be used after fn returns.
+//
+// FindAndIterate returns the error from fn if it is not nil. Otherwise, it returns any error from closing the cursor.
+//
+// See the Find documentation for a description of the filter and opts parameters.
+func (coll *Collection) FindAndIterate(ctx context.Context, filter interface{}, fn func(cur *Cursor) error,
+	opts ...*options.FindOptions) (err error) {
+
+	if fn == nil {
+		return errors.New("FindAndIterate requires a non-nil function")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := cursor.Close(ctx); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return fn(cursor)
+}
+
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select the document to be
@@ -1396,6 +1915,53 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	return &SingleResult{cur: cursor, reg: coll.registry, err: replaceErrors(err)}
 }
 
+// FindByID executes a find command to find the document whose _id value matches the provided ID and decodes it into
+// result. This is equivalent to running FindOne(ctx, bson.D{{"_id", id}}, opts...).Decode(result).
+//
+// The id parameter is the _id of the document to be found. It can be any value that can be marshalled into BSON,
+// such as a primitive.ObjectID, string, or int. It cannot be nil. If the ID does not match any documents,
+// ErrNoDocuments will be returned.
+//
+// The result parameter must be a pointer to a value that the document can be decoded into.
+//
+// The opts parameter can be used to specify options for this operation (see the options.FindOneOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/find/.
+func (coll *Collection) FindByID(ctx context.Context, id interface{}, result interface{},
+	opts ...*options.FindOneOptions) error {
+	if id == nil {
+		return ErrNilValue
+	}
+	return coll.FindOne(ctx, bson.D{{"_id", id}}, opts...).Decode(result)
+}
+
+// FindOneWithHint executes a find command that uses the given index and returns a SingleResult for one document in the
+// collection. This is equivalent to running FindOne(ctx, filter, opts..., options.FindOne().SetHint(hint)).
+//
+// The hint parameter must be the name of an index as a string or an index specification document such as
+// bson.D{{"x", 1}}. Any other type is rejected before the command is sent. The hint overrides the Hint option of the
+// opts parameter.
+//
+// The filter and opts parameters behave as they do for FindOne.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/find/.
+func (coll *Collection) FindOneWithHint(ctx context.Context, filter interface{}, hint interface{},
+	opts ...*options.FindOneOptions) *SingleResult {
+
+	hintVal, err := transformValue(coll.registry, hint, false, "hint")
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+	if hintVal.Type != bsontype.String && hintVal.Type != bsontype.EmbeddedDocument {
+		return &SingleResult{err: fmt.Errorf("hint must be a string or a document, but got BSON type %s", hintVal.Type)}
+	}
+
+	findOneOpts := make([]*options.FindOneOptions, 0, len(opts)+1)
+	findOneOpts = append(findOneOpts, opts...)
+	findOneOpts = append(findOneOpts, options.FindOne().SetHint(hint))
+	return coll.FindOne(ctx, filter, findOneOpts...)
+}
+
 func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAndModify) *SingleResult {
 	if ctx == nil {
 		ctx = context.Background()
@@ -1538,11 +2104,13 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 	if err != nil {
 		return &SingleResult{err: err}
 	}
-	if firstElem, err := r.IndexErr(0); err == nil && strings.HasPrefix(firstElem.Key(), "$") {
-		return &SingleResult{err: errors.New("replacement document cannot contain keys beginning with '$'")}
-	}
 
 	fo := options.MergeFindOneAndReplaceOptions(opts...)
+	if fo.SkipOperatorCheck == nil || !*fo.SkipOperatorCheck {
+		if err := ensureNoDollarKey(r); err != nil {
+			return &SingleResult{err: err}
+		}
+	}
 	op := operation.NewFindAndModify(f).Update(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: r}).
 		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).MaxTime(fo.MaxTime)
 	if fo.BypassDocumentValidation != nil && *fo.BypassDocumentValidation {
@@ -1627,7 +2195,7 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).
 		MaxTime(fo.MaxTime)
 
-	u, err := transformUpdateValue(coll.registry, update, true)
+	u, err := transformUpdateValue(coll.registry, update, ensureDollarKey)
 	if err != nil {
 		return &SingleResult{err: err}
 	}
@@ -1720,11 +2288,179 @@ func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
 
+// WatchForever returns a best-effort change stream for all changes on the corresponding collection. It behaves like
+// Watch, except that the change stream restarts instead of failing when the server returns a ChangeStreamHistoryLost
+// error because its resume point is no longer in the oplog, or when an event has no resume token, which Watch reports
+// as ErrMissingResumeToken. The change stream restarts from the most recent operation time reported by the server, or
+// from the server's current time if no operation time is known. Events that occurred between the lost resume point
+// and the restart are not returned. An event without a resume token is still returned, and the change stream restarts
+// before the next event is read. After 3 restarts during a single call to Next or TryNext, the next
+// ChangeStreamHistoryLost error is returned. All other errors are handled as they are by Watch.
+//
+// The onRestart function is called with the time that the change stream restarts from each time it restarts, so
+// callers can record the potential loss of events. It can be nil.
+//
+// See the Watch documentation for a description of the pipeline and opts parameters.
+func (coll *Collection) WatchForever(ctx context.Context, pipeline interface{}, onRestart func(time.Time),
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
+	if onRestart == nil {
+		onRestart = func(time.Time) {}
+	}
+	csConfig := changeStreamConfig{
+		readConcern:    coll.readConcern,
+		readPreference: coll.readPreference,
+		client:         coll.client,
+		registry:       coll.registry,
+		streamType:     CollectionStream,
+		collectionName: coll.Name(),
+		databaseName:   coll.db.Name(),
+		crypt:          coll.client.cryptFLE,
+		onRestart:      onRestart,
+	}
+	return newChangeStream(ctx, csConfig, pipeline, opts...)
+}
+
 // Indexes returns an IndexView instance that can be used to perform operations on the indexes for the collection.
 func (coll *Collection) Indexes() IndexView {
 	return IndexView{coll: coll}
 }
 
+// CreateTTLIndex creates an ascending index on the given field that the server uses to delete documents once the
+// value of the field is older than expireAfter, and returns the name of the index. The field must hold dates or arrays
+// of dates for documents to expire. This is equivalent to creating the index with IndexView.CreateOne and the
+// ExpireAfterSeconds index option.
+//
+// The fieldName parameter cannot be empty. The expireAfter parameter cannot be negative and is truncated to whole
+// seconds, which is the precision used by the server.
+func (coll *Collection) CreateTTLIndex(ctx context.Context, fieldName string, expireAfter time.Duration) (string, error) {
+	if fieldName == "" {
+		return "", errors.New("fieldName cannot be empty")
+	}
+	if expireAfter < 0 {
+		return "", fmt.Errorf("expireAfter must be non-negative, got %v", expireAfter)
+	}
+	seconds := int64(expireAfter / time.Second)
+	if seconds > math.MaxInt32 {
+		return "", fmt.Errorf("expireAfter must be at most %d seconds, got %v", math.MaxInt32, expireAfter)
+	}
+
+	model := IndexModel{
+		Keys:    bson.D{{fieldName, 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(seconds)),
+	}
+	return coll.Indexes().CreateOne(ctx, model)
+}
+
+// Compact executes a compact command to rewrite and defragment the data and indexes of the collection. The command
+// blocks operations on the collection on some server versions, so it should be run during a maintenance window.
+//
+// The opts parameter can be used to specify options for the operation (see the options.CompactOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/compact/.
+func (coll *Collection) Compact(ctx context.Context, opts ...*options.CompactOptions) (*CompactResult, error) {
+	co := options.MergeCompactOptions(opts...)
+	cmd := bson.D{{"compact", coll.name}}
+	if co.Force != nil {
+		cmd = append(cmd, bson.E{"force", *co.Force})
+	}
+	if co.FreeSpaceTargetMB != nil {
+		cmd = append(cmd, bson.E{"freeSpaceTargetMB", *co.FreeSpaceTargetMB})
+	}
+	if co.Comment != nil {
+		cmd = append(cmd, bson.E{"comment", *co.Comment})
+	}
+
+	var res CompactResult
+	if err := coll.db.RunCommand(ctx, cmd).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Validate executes a validate command to check the integrity of the data and indexes of the collection. The command
+// can be resource intensive, particularly if the Full option is set, and may block operations on the collection.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ValidateOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/validate/.
+func (coll *Collection) Validate(ctx context.Context, opts ...*options.ValidateOptions) (*ValidationResult, error) {
+	vo := options.MergeValidateOptions(opts...)
+	cmd := bson.D{{"validate", coll.name}}
+	if vo.Full != nil {
+		cmd = append(cmd, bson.E{"full", *vo.Full})
+	}
+	if vo.Repair != nil {
+		cmd = append(cmd, bson.E{"repair", *vo.Repair})
+	}
+
+	var res ValidationResult
+	if err := coll.db.RunCommand(ctx, cmd).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SetValidator executes a collMod command to set the validator of the collection. The schema parameter is sent as the
+// validator as is and must be a document, such as a bson.D or a struct that marshals to one. To validate documents
+// against a JSON Schema, wrap the schema in a $jsonSchema query operator, e.g. bson.D{{"$jsonSchema", schema}}. The
+// document returned by GetValidator can be passed back to SetValidator, and an empty document removes the validator.
+//
+// The validationLevel and validationAction parameters set the validationLevel and validationAction fields of the
+// command (e.g. "strict" or "moderate", and "error" or "warn"). An empty string leaves the corresponding setting of
+// the collection unchanged.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/collMod/.
+func (coll *Collection) SetValidator(ctx context.Context, schema interface{}, validationLevel,
+	validationAction string) error {
+
+	doc, err := transformBsoncoreDocument(coll.registry, schema, true, "schema")
+	if err != nil {
+		return err
+	}
+
+	cmd := bson.D{{"collMod", coll.name}, {"validator", bson.Raw(doc)}}
+	if validationLevel != "" {
+		cmd = append(cmd, bson.E{"validationLevel", validationLevel})
+	}
+	if validationAction != "" {
+		cmd = append(cmd, bson.E{"validationAction", validationAction})
+	}
+	return coll.db.RunCommand(ctx, cmd).Err()
+}
+
+// GetValidator executes a listCollections command to retrieve the validator of the collection. It returns nil and no
+// error if the collection has no validator or its validator has been removed, and an error if the collection does not
+// exist.
+func (coll *Collection) GetValidator(ctx context.Context) (bson.Raw, error) {
+	specs, err := coll.db.ListCollectionSpecifications(ctx, bson.D{{"name", coll.name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("collection %q does not exist in database %q", coll.name, coll.db.name)
+	}
+	if len(specs[0].Options) == 0 {
+		return nil, nil
+	}
+
+	val, err := specs[0].Options.LookupErr("validator")
+	if err == bsoncore.ErrElementNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	validator, ok := val.DocumentOK()
+	if !ok {
+		return nil, fmt.Errorf("expected validator of %v to be document, got %v", coll.name, val.Type)
+	}
+	if len(validator) == bsoncore.EmptyDocumentLength {
+		return nil, nil
+	}
+	return validator, nil
+}
+
 // Drop drops the collection on the server. This method ignores "namespace not found" errors so it is safe to drop
 // a collection that does not exist on the server.
 func (coll *Collection) Drop(ctx context.Context) error {
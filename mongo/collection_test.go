@@ -8,7 +8,10 @@ package mongo
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
@@ -16,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 const (
@@ -128,11 +132,175 @@ func TestCollection(t *testing.T) {
 		err = coll.FindOneAndUpdate(bgCtx, doc, update).Err()
 		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
 	})
+	t.Run("invalid index key", func(t *testing.T) {
+		coll := setupColl("foo")
+
+		_, err := coll.Indexes().CreateOne(bgCtx, IndexModel{Keys: bson.D{{"$natural", 1}}})
+		assert.True(t, errors.Is(err, ErrInvalidIndexKey), "expected error %v, got %v", ErrInvalidIndexKey, err)
+		assert.True(t, strings.Contains(err.Error(), "$natural"), "expected error to contain key name, got %v", err)
+
+		models := []IndexModel{{Keys: bson.D{{"x", 1}}}, {Keys: bson.D{{"y", 1}, {"$foo", 1}}}}
+		_, err = coll.Indexes().CreateMany(bgCtx, models)
+		assert.True(t, errors.Is(err, ErrInvalidIndexKey), "expected error %v, got %v", ErrInvalidIndexKey, err)
+
+		_, err = coll.Indexes().CreateOne(bgCtx, IndexModel{Keys: bson.D{{"$**", 1}}})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("update pipeline", func(t *testing.T) {
+		coll := setupColl("foo")
+		filter := bson.D{{"x", 1}}
+
+		_, err := coll.UpdateOnePipeline(bgCtx, filter, Pipeline{
+			{{"$set", bson.D{{"y", 1}}}},
+			{{"$match", bson.D{{"y", 1}}}},
+		})
+		assert.True(t, errors.Is(err, ErrInvalidUpdatePipelineStage),
+			"expected error %v, got %v", ErrInvalidUpdatePipelineStage, err)
+		assert.True(t, strings.Contains(err.Error(), "$match"), "expected error to contain stage name, got %v", err)
+
+		_, err = coll.UpdateOnePipeline(bgCtx, filter, Pipeline{{{"$set", bson.D{{"y", 1}}}, {"$unset", "z"}}})
+		assert.True(t, errors.Is(err, ErrInvalidUpdatePipelineStage),
+			"expected error %v, got %v", ErrInvalidUpdatePipelineStage, err)
+
+		_, err = coll.UpdateOnePipeline(bgCtx, filter, Pipeline{})
+		assert.NotNil(t, err, "expected error for empty pipeline, got nil")
+
+		_, err = coll.UpdateOnePipeline(bgCtx, filter, Pipeline{
+			{{"$set", bson.D{{"y", 1}}}},
+			{{"$unset", "z"}},
+			{{"$replaceWith", "$doc"}},
+		})
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("replacement operator check", func(t *testing.T) {
+		replacement := bson.D{{"x", 1}, {"$y", 1}}
+
+		coll := setupColl("foo")
+		err := coll.FindOneAndReplace(bgCtx, bson.D{}, replacement).Err()
+		assert.True(t, errors.Is(err, ErrDocumentContainsUpdateOperators),
+			"expected error %v, got %v", ErrDocumentContainsUpdateOperators, err)
+		_, err = coll.BulkWrite(bgCtx, []WriteModel{NewReplaceOneModel().SetFilter(bson.D{}).SetReplacement(replacement)})
+		assert.True(t, errors.Is(err, ErrDocumentContainsUpdateOperators),
+			"expected error %v, got %v", ErrDocumentContainsUpdateOperators, err)
+
+		// With SkipOperatorCheck, the replacement is sent to the server as is.
+		client := setupClient()
+		deployment := newCommandDeployment(17, bson.D{{"ok", 1}, {"value", nil}}, bson.D{{"ok", 1}, {"n", 1}, {"nModified", 1}})
+		deployment.conn.Desc.MaxDocumentSize = 16 * 1024 * 1024
+		deployment.conn.Desc.MaxMessageSize = 48 * 1024 * 1024
+		deployment.conn.Desc.MaxBatchCount = 100000
+		client.deployment = deployment
+		client.sessionPool = session.NewPool(nil)
+		coll = client.Database("db").Collection("coll")
+
+		opts := options.FindOneAndReplace().SetSkipOperatorCheck(true)
+		err = coll.FindOneAndReplace(bgCtx, bson.D{}, replacement, opts).Err()
+		assert.Equal(t, ErrNoDocuments, err, "expected error %v, got %v", ErrNoDocuments, err)
+		model := NewReplaceOneModel().SetFilter(bson.D{}).SetReplacement(replacement).SetSkipOperatorCheck(true)
+		_, err = coll.BulkWrite(bgCtx, []WriteModel{model})
+		assert.Nil(t, err, "BulkWrite error: %v", err)
+
+		cmds := deployment.commands(t)
+		assert.Equal(t, 2, len(cmds), "expected 2 commands, got %v", len(cmds))
+		_, err = cmds[0].LookupErr("update", "$y")
+		assert.Nil(t, err, "expected $y in findAndModify update, got %v", cmds[0])
+		cmdName := cmds[1].Index(0).Key()
+		assert.Equal(t, "update", cmdName, "expected update command, got %v", cmdName)
+	})
 	t.Run("database accessor", func(t *testing.T) {
 		coll := setupColl("bar")
 		dbName := coll.Database().Name()
 		assert.Equal(t, testDbName, dbName, "expected db name %v, got %v", testDbName, dbName)
 	})
+	t.Run("namespace", func(t *testing.T) {
+		coll := setupColl("bar")
+		expected := testDbName + ".bar"
+		ns := coll.Namespace()
+		assert.Equal(t, expected, ns, "expected namespace %v, got %v", expected, ns)
+	})
+	t.Run("paginated find validation", func(t *testing.T) {
+		coll := setupColl("foo")
+		_, err := coll.PaginatedFind(bgCtx, bson.D{}, 0, nil, nil)
+		assert.NotNil(t, err, "expected error for page size 0, got nil")
+		_, err = coll.PaginatedFind(bgCtx, nil, 10, nil, nil)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+		_, err = coll.PaginatedFind(bgCtx, bson.D{}, 10, nil, bson.D{{"x", 1}})
+		assert.NotNil(t, err, "expected error for sort on another field, got nil")
+		_, err = coll.PaginatedFind(bgCtx, bson.D{}, 10, nil, bson.D{{"_id", 1}, {"x", 1}})
+		assert.NotNil(t, err, "expected error for compound sort, got nil")
+		_, err = coll.PaginatedFind(bgCtx, bson.D{}, 10, nil, bson.D{{"_id", 0.5}})
+		assert.NotNil(t, err, "expected error for fractional sort direction, got nil")
+		assert.NotEqual(t, ErrClientDisconnected, err, "expected sort validation error, got %v", err)
+		for _, dir := range []float64{1, -1} {
+			_, err = coll.PaginatedFind(bgCtx, bson.D{}, 10, nil, bson.D{{"_id", dir}})
+			assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+		}
+	})
+	t.Run("count by field validation", func(t *testing.T) {
+		coll := setupColl("foo")
+		_, err := coll.CountByField(bgCtx, "", 1)
+		assert.NotNil(t, err, "expected error for empty field name, got nil")
+		_, err = coll.CountByField(bgCtx, "$where", 1)
+		assert.NotNil(t, err, "expected error for field name beginning with '$', got nil")
+		assert.NotEqual(t, ErrClientDisconnected, err, "expected field name error, got %v", err)
+		_, err = coll.CountByField(bgCtx, "a.b", 1)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
+	t.Run("count documents estimated max time", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			filter  interface{}
+			reply   bson.D
+			command string
+		}{
+			{"estimated", nil, bson.D{{"ok", 1}, {"n", 3}}, "count"},
+			{"exact", bson.D{{"x", 1}}, newCursorReply(0, "firstBatch", bson.D{{"n", 3}}), "aggregate"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client := setupClient()
+				deployment := newCommandDeployment(17, tc.reply)
+				client.deployment = deployment
+				client.sessionPool = session.NewPool(nil)
+				coll := client.Database("db").Collection("coll")
+
+				n, err := coll.CountDocumentsEstimated(bgCtx, tc.filter, 250)
+				assert.Nil(t, err, "CountDocumentsEstimated error: %v", err)
+				assert.Equal(t, int64(3), n, "expected count 3, got %v", n)
+
+				cmd := deployment.commands(t)[0]
+				assert.Equal(t, tc.command, cmd.Index(0).Key(), "expected %v command, got %v", tc.command, cmd)
+				maxTimeMS := cmd.Lookup("maxTimeMS").Int64()
+				assert.Equal(t, int64(250), maxTimeMS, "expected maxTimeMS 250, got %v", maxTimeMS)
+			})
+		}
+	})
+	t.Run("create TTL index validation", func(t *testing.T) {
+		coll := setupColl("foo")
+		_, err := coll.CreateTTLIndex(bgCtx, "", time.Hour)
+		assert.NotNil(t, err, "expected error for empty field name, got nil")
+		_, err = coll.CreateTTLIndex(bgCtx, "createdAt", -time.Second)
+		assert.NotNil(t, err, "expected error for negative duration, got nil")
+		_, err = coll.CreateTTLIndex(bgCtx, "createdAt", time.Duration(math.MaxInt32+1)*time.Second)
+		assert.NotNil(t, err, "expected error for duration out of range, got nil")
+	})
+	t.Run("find one with hint validation", func(t *testing.T) {
+		coll := setupColl("foo")
+		err := coll.FindOneWithHint(bgCtx, bson.D{}, nil).Err()
+		assert.Equal(t, ErrNilValue, err, "expected error %v, got %v", ErrNilValue, err)
+		err = coll.FindOneWithHint(bgCtx, bson.D{}, 1).Err()
+		assert.NotNil(t, err, "expected error for int hint, got nil")
+		err = coll.FindOneWithHint(bgCtx, bson.D{}, bson.A{"x_1"}).Err()
+		assert.NotNil(t, err, "expected error for array hint, got nil")
+		err = coll.FindOneWithHint(bgCtx, bson.D{}, map[string]int{"x": 1, "y": 1}).Err()
+		expected := ErrMapForOrderedArgument{"hint"}
+		assert.Equal(t, expected, err, "expected error %v, got %v", expected, err)
+	})
+	t.Run("set validator validation", func(t *testing.T) {
+		coll := setupColl("foo")
+		err := coll.SetValidator(bgCtx, nil, "", "")
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+	})
 	t.Run("nil document error", func(t *testing.T) {
 		coll := setupColl("foo")
 		doc := bson.D{}
@@ -146,6 +314,13 @@ func TestCollection(t *testing.T) {
 		_, err = coll.InsertMany(bgCtx, []interface{}{})
 		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
 
+		_, err = coll.InsertManyWithIDs(bgCtx, nil)
+		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+
+		ids, err := coll.InsertManyWithIDs(bgCtx, []interface{}{doc, nil})
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+		assert.Nil(t, ids, "expected no IDs, got %v", ids)
+
 		_, err = coll.DeleteOne(bgCtx, nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
@@ -206,6 +381,15 @@ func TestCollection(t *testing.T) {
 		_, err = coll.BulkWrite(bgCtx, []WriteModel{nil})
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 
+		_, _, err = coll.InsertOrFind(bgCtx, nil)
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
+		_, err = coll.BulkWriteWithResult(bgCtx, nil)
+		assert.Equal(t, ErrEmptySlice, err, "expected error %v, got %v", ErrEmptySlice, err)
+
+		_, err = coll.BulkWriteWithResult(bgCtx, []WriteModel{nil})
+		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+
 		aggErr := errors.New("can only transform slices and arrays into aggregation pipelines, but got invalid")
 		_, err = coll.Aggregate(bgCtx, nil)
 		assert.Equal(t, aggErr, err, "expected error %v, got %v", aggErr, err)
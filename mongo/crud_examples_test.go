@@ -604,7 +604,7 @@ func ExampleCollection_Watch() {
 
 // Session examples
 
-func ExampleWithSession() {
+func ExampleWithSessionContext() {
 	// Assume client is configured with write concern majority and read
 	// preference primary.
 	var client *mongo.Client
@@ -621,8 +621,8 @@ func ExampleWithSession() {
 	}
 	defer sess.EndSession(context.TODO())
 
-	// Call WithSession to start a transaction within the new session.
-	err = mongo.WithSession(
+	// Call WithSessionContext to start a transaction within the new session.
+	err = mongo.WithSessionContext(
 		context.TODO(),
 		sess,
 		func(ctx mongo.SessionContext) error {
@@ -639,7 +639,7 @@ func ExampleWithSession() {
 			if err != nil {
 				// Abort the transaction after an error. Use
 				// context.Background() to ensure that the abort can complete
-				// successfully even if the context passed to mongo.WithSession
+				// successfully even if the context passed to mongo.WithSessionContext
 				// is changed to have a timeout.
 				_ = sess.AbortTransaction(context.Background())
 				return err
@@ -653,7 +653,7 @@ func ExampleWithSession() {
 			if err != nil {
 				// Abort the transaction after an error. Use
 				// context.Background() to ensure that the abort can complete
-				// successfully even if the context passed to mongo.WithSession
+				// successfully even if the context passed to mongo.WithSessionContext
 				// is changed to have a timeout.
 				_ = sess.AbortTransaction(context.Background())
 				return err
@@ -661,7 +661,7 @@ func ExampleWithSession() {
 			fmt.Println(result)
 
 			// Use context.Background() to ensure that the commit can complete
-			// successfully even if the context passed to mongo.WithSession is
+			// successfully even if the context passed to mongo.WithSessionContext is
 			// changed to have a timeout.
 			return sess.CommitTransaction(context.Background())
 		})
@@ -696,7 +696,7 @@ func ExampleClient_UseSessionWithOptions() {
 			if err != nil {
 				// Abort the transaction after an error. Use
 				// context.Background() to ensure that the abort can complete
-				// successfully even if the context passed to mongo.WithSession
+				// successfully even if the context passed to mongo.WithSessionContext
 				// is changed to have a timeout.
 				_ = ctx.AbortTransaction(context.Background())
 				return err
@@ -710,7 +710,7 @@ func ExampleClient_UseSessionWithOptions() {
 			if err != nil {
 				// Abort the transaction after an error. Use
 				// context.Background() to ensure that the abort can complete
-				// successfully even if the context passed to mongo.WithSession
+				// successfully even if the context passed to mongo.WithSessionContext
 				// is changed to have a timeout.
 				_ = ctx.AbortTransaction(context.Background())
 				return err
@@ -718,7 +718,7 @@ func ExampleClient_UseSessionWithOptions() {
 			fmt.Println(result)
 
 			// Use context.Background() to ensure that the commit can complete
-			// successfully even if the context passed to mongo.WithSession is
+			// successfully even if the context passed to mongo.WithSessionContext is
 			// changed to have a timeout.
 			return ctx.CommitTransaction(context.Background())
 		})
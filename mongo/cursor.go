@@ -270,6 +270,17 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 	return nil
 }
 
+// ToSlice iterates the cursor and returns all remaining documents as a slice of bson.Raw. Each document is a copy and
+// remains valid after the cursor is closed. This method will close the cursor after retrieving all documents, even if
+// an error occurs. If the cursor has been iterated, any previously iterated documents will not be included.
+func (c *Cursor) ToSlice(ctx context.Context) ([]bson.Raw, error) {
+	var docs []bson.Raw
+	if err := c.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
 // RemainingBatchLength returns the number of documents left in the current batch. If this returns zero, the subsequent
 // call to Next or TryNext will do a network request to fetch the next batch.
 func (c *Cursor) RemainingBatchLength() int {
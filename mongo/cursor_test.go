@@ -179,6 +179,23 @@ func TestCursor(t *testing.T) {
 			assert.NotNil(t, err, "expected error, got: %v", err)
 		})
 	})
+	t.Run("TestToSlice", func(t *testing.T) {
+		t.Run("returns all documents", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			cursor, err := newCursor(tbc, nil)
+			assert.Nil(t, err, "newCursor error: %v", err)
+
+			docs, err := cursor.ToSlice(context.Background())
+			assert.Nil(t, err, "ToSlice error: %v", err)
+			assert.Equal(t, 10, len(docs), "expected 10 docs, got %v", len(docs))
+			assert.True(t, tbc.closed, "expected batch cursor to be closed but was not")
+
+			for index, doc := range docs {
+				expected := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendInt32Element(nil, "foo", int32(index)))
+				assert.Equal(t, bson.Raw(expected), doc, "expected doc %v, got %v", bson.Raw(expected), doc)
+			}
+		})
+	})
 }
 
 func TestNewCursorFromDocuments(t *testing.T) {
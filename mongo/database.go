@@ -510,6 +510,95 @@ func (db *Database) Watch(ctx context.Context, pipeline interface{},
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
 
+// maxViewDepth is the maximum number of nested views that WatchView will resolve before giving up.
+const maxViewDepth = 20
+
+// WatchView returns a change stream for all changes to the collection that the view with the given name is
+// defined on. The view's aggregation pipeline is prepended to the pipeline parameter so that the returned change
+// stream only reports events that the view's stages would let through. Views defined on other views are resolved
+// recursively until a collection is reached.
+//
+// Change streams cannot be opened on views directly, so this method has several limitations:
+//
+// 1. The view's stages are applied to change events, not to the documents in the collection. For example, a $match
+// stage on the field "status" will only match events that have a top-level "status" field, so most views need to
+// filter on "fullDocument.status" instead to be useful with this method.
+//
+// 2. Every stage in the view's pipeline must be one of the stages that is allowed in a change stream ($addFields,
+// $match, $project, $redact, $replaceRoot, $replaceWith, $set, and $unset). Views that use other stages are rejected
+// with an error wrapping ErrInvalidChangeStreamStage.
+//
+// 3. The view definition is read once when the change stream is created. Changes to the view are not reflected in
+// an existing change stream, including when it is resumed.
+//
+// The pipeline and opts parameters behave as they do for Collection.Watch.
+func (db *Database) WatchView(ctx context.Context, viewName string, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
+	source, viewStages, err := db.resolveView(ctx, viewName)
+	if err != nil {
+		return nil, err
+	}
+
+	combined, err := viewChangeStreamPipeline(viewName, viewStages, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	return db.Collection(source).Watch(ctx, combined, opts...)
+}
+
+// resolveView returns the name of the collection that the view with the given name is ultimately defined on and the
+// stages of all views in between, in the order that the server would apply them.
+func (db *Database) resolveView(ctx context.Context, viewName string) (string, []bson.Raw, error) {
+	var stages []bson.Raw
+	name := viewName
+	for i := 0; i < maxViewDepth; i++ {
+		specs, err := db.ListCollectionSpecifications(ctx, bson.D{{"name", name}})
+		if err != nil {
+			return "", nil, err
+		}
+		if len(specs) == 0 || specs[0].Type != "view" {
+			if i == 0 {
+				return "", nil, fmt.Errorf("%q is not a view in database %q", viewName, db.name)
+			}
+			return name, stages, nil
+		}
+
+		viewOn, ok := specs[0].Options.Lookup("viewOn").StringValueOK()
+		if !ok {
+			return "", nil, fmt.Errorf("view %q has no viewOn collection", name)
+		}
+		var viewStages []bson.Raw
+		if val, err := specs[0].Options.LookupErr("pipeline"); err == nil {
+			if err := val.Unmarshal(&viewStages); err != nil {
+				return "", nil, fmt.Errorf("error decoding pipeline of view %q: %w", name, err)
+			}
+		}
+
+		// The stages of the view being resolved are applied before those of the views built on top of it.
+		stages = append(viewStages, stages...)
+		name = viewOn
+	}
+	return "", nil, fmt.Errorf("view %q is nested more than %d levels deep", viewName, maxViewDepth)
+}
+
+// viewChangeStreamPipeline validates that the stages of the view with the given name can be used in a change stream
+// and returns a pipeline consisting of those stages followed by the stages of pipeline.
+func viewChangeStreamPipeline(viewName string, viewStages []bson.Raw, pipeline interface{}) (bson.A, error) {
+	stages := make(bson.A, 0, len(viewStages))
+	for i, stage := range viewStages {
+		elem, err := stage.IndexErr(0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: view %q stage :%v: is empty", ErrInvalidChangeStreamStage, viewName, i)
+		}
+		if _, ok := changeStreamAllowedStages[elem.Key()]; !ok {
+			return nil, fmt.Errorf("%w: view %q stage :%v: %q", ErrInvalidChangeStreamStage, viewName, i, elem.Key())
+		}
+		stages = append(stages, stage)
+	}
+	return prependPipelineStages(stages, pipeline)
+}
+
 // CreateCollection executes a create command to explicitly create a new collection with the specified name on the
 // server. If the collection being created already exists, this method will return a mongo.CommandError. This method
 // requires driver version 1.4.0 or higher.
@@ -18,6 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 func setupDb(name string, opts ...*options.DatabaseOptions) *Database {
@@ -110,4 +111,25 @@ func TestDatabase(t *testing.T) {
 		_, err = db.ListCollectionNames(context.Background(), nil)
 		assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
 	})
+	t.Run("view change stream pipeline", func(t *testing.T) {
+		match := bson.Raw(bsoncore.NewDocumentBuilder().
+			AppendDocument("$match", bsoncore.NewDocumentBuilder().AppendString("fullDocument.status", "A").Build()).
+			Build())
+		group := bson.Raw(bsoncore.NewDocumentBuilder().
+			AppendDocument("$group", bsoncore.NewDocumentBuilder().AppendString("_id", "$x").Build()).
+			Build())
+		userStage := bson.D{{"$project", bson.D{{"fullDocument", 1}}}}
+
+		got, err := viewChangeStreamPipeline("v", []bson.Raw{match}, Pipeline{userStage})
+		assert.Nil(t, err, "viewChangeStreamPipeline error: %v", err)
+		expected := bson.A{match, userStage}
+		assert.Equal(t, expected, got, "expected pipeline %v, got %v", expected, got)
+
+		_, err = viewChangeStreamPipeline("v", []bson.Raw{match, group}, Pipeline{})
+		assert.True(t, errors.Is(err, ErrInvalidChangeStreamStage),
+			"expected error %v, got %v", ErrInvalidChangeStreamStage, err)
+
+		_, err = viewChangeStreamPipeline("v", []bson.Raw{match}, nil)
+		assert.NotNil(t, err, "expected error for nil pipeline, got nil")
+	})
 }
@@ -26,6 +26,10 @@ var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 // ErrClientDisconnected is returned when disconnected Client is used to run an operation.
 var ErrClientDisconnected = errors.New("client is disconnected")
 
+// ErrConnectCancelled is returned by Client.Connect if the context passed to it has already expired. The error returned
+// by the driver matches both this error and the context error, so errors.Is should be used to check for either.
+var ErrConnectCancelled = errors.New("client connect cancelled")
+
 // ErrNilDocument is returned when a nil document is passed to a CRUD method.
 var ErrNilDocument = errors.New("document is nil")
 
@@ -35,6 +39,22 @@ var ErrNilValue = errors.New("value is nil")
 // ErrEmptySlice is returned when an empty slice is passed to a CRUD method that requires a non-empty slice.
 var ErrEmptySlice = errors.New("must provide at least one element in input slice")
 
+// ErrDocumentContainsUpdateOperators is returned when a replacement document contains top-level keys beginning with
+// '$'. The error returned by the driver wraps this error and includes the offending keys, so errors.Is should be used
+// to check for it.
+var ErrDocumentContainsUpdateOperators = errors.New("replacement document cannot contain keys beginning with '$'")
+
+// ErrNoUpdateOperators is returned by update operations when the update is a document whose first key does not begin
+// with '$' (e.g. bson.M{"x": 1} instead of bson.M{"$set": bson.M{"x": 1}}). Update pipelines are not affected. The
+// error returned by the driver wraps this error and includes the offending key, so errors.Is should be used to check
+// for it.
+var ErrNoUpdateOperators = errors.New("update document must contain key beginning with '$'")
+
+// ErrInvalidUpdatePipelineStage is returned by Collection.UpdateOnePipeline if the pipeline contains a stage that is not
+// allowed in an update pipeline. The error returned by the driver wraps this error and includes the index of the
+// offending stage, so errors.Is should be used to check for it.
+var ErrInvalidUpdatePipelineStage = errors.New("invalid update pipeline stage")
+
 // ErrMapForOrderedArgument is returned when a map with multiple keys is passed to a CRUD method for an ordered parameter
 type ErrMapForOrderedArgument struct {
 	ParamName string
@@ -45,6 +65,119 @@ func (e ErrMapForOrderedArgument) Error() string {
 	return fmt.Sprintf("multi-key map passed in for ordered parameter %v", e.ParamName)
 }
 
+// ErrServerTooOld is returned by Client.CheckCompatibility when a server is older than the minimum server version
+// supported by the driver.
+type ErrServerTooOld struct {
+	// The address of the server.
+	Address string
+
+	// The minimum server version supported by the driver.
+	MinVersion string
+
+	// The version of the server, derived from the maximum wire version it reports.
+	ActualVersion string
+}
+
+// Error implements the error interface.
+func (e ErrServerTooOld) Error() string {
+	return fmt.Sprintf("server at %s has version %s, but this version of the Go driver requires at least MongoDB %s",
+		e.Address, e.ActualVersion, e.MinVersion)
+}
+
+// ChangeStreamDecodeError is sent to the channel returned by ChangeStream.DecodeErrors when an event is skipped because
+// it could not be decoded.
+type ChangeStreamDecodeError struct {
+	// The resume token of the change stream after the skipped event was returned.
+	ResumeToken bson.Raw
+
+	// A copy of the event that could not be decoded.
+	Event bson.Raw
+
+	// The error returned when decoding the event.
+	Err error
+}
+
+// Error implements the error interface.
+func (e ChangeStreamDecodeError) Error() string {
+	return "error decoding change stream event: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying decode error.
+func (e ChangeStreamDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedChangeStreamOptionError is returned when a change stream is opened or resumed with an option that the
+// server does not support. The error is returned by the driver before the aggregate command is sent, instead of the
+// error the server would return for an unknown $changeStream field.
+type UnsupportedChangeStreamOptionError struct {
+	// The name of the $changeStream option, e.g. "showExpandedEvents".
+	Option string
+
+	// The minimum wire version that supports the option.
+	MinWireVersion int32
+
+	// The maximum wire version of the server.
+	Have int32
+}
+
+// Error implements the error interface.
+func (e UnsupportedChangeStreamOptionError) Error() string {
+	return fmt.Sprintf("change stream option %q requires wire version %d, but the server's maximum wire version is %d",
+		e.Option, e.MinWireVersion, e.Have)
+}
+
+// documentValidationFailure is the error code returned by the server when a document fails schema validation.
+const documentValidationFailure int32 = 121
+
+// AggregationValidationError is returned by Aggregate when a document written by a $merge or $out stage fails the
+// schema validation of the target collection. The server reports the _id of the failing document rather than the
+// document itself. Use errors.As to check for this error; the underlying CommandError is still available through
+// errors.As as well.
+type AggregationValidationError struct {
+	// The _id of the document that failed validation, from the failingDocumentId field of the server's errInfo. This
+	// is empty if the server did not report it.
+	FailingDocumentID bson.RawValue
+
+	// The details of the validation failure, from the details field of the server's errInfo. This is nil if the server
+	// did not report them, which is the case for MongoDB versions < 5.0.
+	Details bson.Raw
+
+	// The error returned by the server.
+	Err CommandError
+}
+
+// Error implements the error interface.
+func (e AggregationValidationError) Error() string {
+	return "aggregation document failed validation: " + e.Err.Error()
+}
+
+// Unwrap returns the underlying CommandError.
+func (e AggregationValidationError) Unwrap() error {
+	return e.Err
+}
+
+// AsAggregationValidationError returns the details of a document validation failure if err is or wraps a CommandError
+// with code 121 (DocumentValidationFailure), such as an error returned by RunCommand for an aggregate command with a
+// $merge or $out stage. It returns false otherwise.
+func AsAggregationValidationError(err error) (AggregationValidationError, bool) {
+	var ce CommandError
+	if !errors.As(err, &ce) || ce.Code != documentValidationFailure {
+		return AggregationValidationError{}, false
+	}
+
+	ave := AggregationValidationError{Err: ce}
+	if errInfo, ok := ce.Raw.Lookup("errInfo").DocumentOK(); ok {
+		if id, err := errInfo.LookupErr("failingDocumentId"); err == nil {
+			ave.FailingDocumentID = id
+		}
+		if details, ok := errInfo.Lookup("details").DocumentOK(); ok {
+			ave.Details = details
+		}
+	}
+	return ave, true
+}
+
 func replaceErrors(err error) error {
 	// Return nil when err is nil to avoid costly reflection logic below.
 	if err == nil {
@@ -663,3 +796,21 @@ func joinBatchErrors(errs []error) string {
 
 	return buf.String()
 }
+
+// wrappedSentinelError wraps an error so that errors.Is matches both sentinel and the wrapped error.
+type wrappedSentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e wrappedSentinelError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+func (e wrappedSentinelError) Unwrap() error {
+	return e.err
+}
+
+func (e wrappedSentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
@@ -7,11 +7,14 @@
 package mongo
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 func TestErrorMessages(t *testing.T) {
@@ -70,3 +73,52 @@ func TestErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregationValidationError(t *testing.T) {
+	raw, err := bson.Marshal(bson.D{
+		{"ok", 0},
+		{"code", 121},
+		{"errInfo", bson.D{
+			{"failingDocumentId", 1},
+			{"details", bson.D{{"operatorName", "$jsonSchema"}}},
+		}},
+	})
+	require.Nil(t, err, "unexpected error marshaling BSON")
+
+	ce := CommandError{Code: 121, Name: "DocumentValidationFailure", Message: "Document failed validation", Raw: raw}
+	err = fmt.Errorf("aggregate failed: %w", ce)
+
+	ave, ok := AsAggregationValidationError(err)
+	require.True(t, ok, "expected AggregationValidationError, got %v", err)
+	assert.Equal(t, int32(1), ave.FailingDocumentID.Int32(), "expected failing document ID 1, got %v", ave.FailingDocumentID)
+	assert.Equal(t, "$jsonSchema", ave.Details.Lookup("operatorName").StringValue(),
+		"expected operatorName $jsonSchema in details, got %v", ave.Details)
+	assert.Equal(t, ce, ave.Err, "expected error %v, got %v", ce, ave.Err)
+
+	_, ok = AsAggregationValidationError(CommandError{Code: 11000})
+	assert.False(t, ok, "expected no AggregationValidationError for code 11000")
+	_, ok = AsAggregationValidationError(errors.New("network error"))
+	assert.False(t, ok, "expected no AggregationValidationError for a non-server error")
+
+	t.Run("returned by Aggregate", func(t *testing.T) {
+		client := setupClient()
+		client.deployment = newCommandDeployment(17, bson.D{
+			{"ok", 0},
+			{"code", 121},
+			{"codeName", "DocumentValidationFailure"},
+			{"errmsg", "Document failed validation"},
+			{"errInfo", bson.D{{"failingDocumentId", 1}}},
+		})
+		client.sessionPool = session.NewPool(nil)
+		coll := client.Database("db").Collection("coll")
+
+		_, err := coll.Aggregate(bgCtx, bson.A{bson.D{{"$out", "other"}}})
+		var ave AggregationValidationError
+		require.True(t, errors.As(err, &ave), "expected AggregationValidationError, got %v", err)
+		assert.Equal(t, int32(1), ave.FailingDocumentID.Int32(), "expected failing document ID 1, got %v",
+			ave.FailingDocumentID)
+		var ce CommandError
+		require.True(t, errors.As(err, &ce), "expected error to wrap CommandError, got %v", err)
+		assert.Equal(t, int32(121), ce.Code, "expected code 121, got %v", ce.Code)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -29,9 +30,17 @@ import (
 // or string.
 var ErrInvalidIndexValue = errors.New("invalid index value")
 
+// ErrInvalidIndexKey is returned if an index is created with a keys document that has a field name starting with "$",
+// other than the "$**" wildcard field.
+var ErrInvalidIndexKey = errors.New("invalid index key")
+
 // ErrNonStringIndexName is returned if an index is created with a name that is not a string.
 var ErrNonStringIndexName = errors.New("index name must be a string")
 
+// ErrHiddenIndexNotSupported is returned if an index is created with the Hidden option set to true on a server that does
+// not support hidden indexes. Hidden indexes are only supported by MongoDB versions >= 4.4.
+var ErrHiddenIndexNotSupported = errors.New("hidden indexes are not supported by the server")
+
 // ErrMultipleIndexDrop is returned if multiple indexes would be dropped from a call to IndexView.DropOne.
 var ErrMultipleIndexDrop = errors.New("multiple indexes would be dropped")
 
@@ -176,6 +185,7 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 // For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createIndexes/.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
 	names := make([]string, 0, len(models))
+	var hidden bool
 
 	var indexes bsoncore.Document
 	aidx, indexes := bsoncore.AppendArrayStart(indexes)
@@ -189,6 +199,9 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		if err != nil {
 			return nil, err
 		}
+		if err = validateIndexKeys(keys); err != nil {
+			return nil, err
+		}
 
 		name, err := getOrGenerateIndexName(keys, model)
 		if err != nil {
@@ -205,6 +218,9 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 			model.Options = options.Index()
 		}
 		model.Options.SetName(name)
+		if model.Options.Hidden != nil && *model.Options.Hidden {
+			hidden = true
+		}
 
 		optsDoc, err := iv.createOptionsDoc(model.Options)
 		if err != nil {
@@ -251,7 +267,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 
 	option := options.MergeCreateIndexesOptions(opts...)
 
-	op := operation.NewCreateIndexes(indexes).
+	op := operation.NewCreateIndexes(indexes).Hidden(hidden).
 		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
 		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).
@@ -266,6 +282,9 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	}
 
 	err = op.Execute(ctx)
+	if errors.Is(err, operation.ErrHiddenIndexNotSupported) {
+		return nil, ErrHiddenIndexNotSupported
+	}
 	if err != nil {
 		_, err = processWriteError(err)
 		return nil, err
@@ -274,6 +293,31 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	return names, nil
 }
 
+// HideIndex executes a collMod command to hide an existing index from the query planner. The index is still updated
+// by writes, so it can be unhidden with UnhideIndex without being rebuilt. Hidden indexes are only supported by MongoDB
+// versions >= 4.4.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/collMod/.
+func (iv IndexView) HideIndex(ctx context.Context, name string) error {
+	return iv.setHidden(ctx, name, true)
+}
+
+// UnhideIndex executes a collMod command to make an index that was hidden with HideIndex, or created with the Hidden
+// option, visible to the query planner again. Hidden indexes are only supported by MongoDB versions >= 4.4.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/collMod/.
+func (iv IndexView) UnhideIndex(ctx context.Context, name string) error {
+	return iv.setHidden(ctx, name, false)
+}
+
+func (iv IndexView) setHidden(ctx context.Context, name string, hidden bool) error {
+	cmd := bson.D{
+		{"collMod", iv.coll.name},
+		{"index", bson.D{{"name", name}, {"hidden", hidden}}},
+	}
+	return iv.coll.db.RunCommand(ctx, cmd).Err()
+}
+
 func (iv IndexView) createOptionsDoc(opts *options.IndexOptions) (bsoncore.Document, error) {
 	optsDoc := bsoncore.Document{}
 	if opts.Background != nil {
@@ -441,6 +485,22 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 	return iv.drop(ctx, "*", opts...)
 }
 
+// validateIndexKeys returns an error wrapping ErrInvalidIndexKey if any field in keys starts with "$", other than the
+// "$**" wildcard field.
+func validateIndexKeys(keys bsoncore.Document) error {
+	elems, err := keys.Elements()
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		key := elem.Key()
+		if strings.HasPrefix(key, "$") && key != "$**" {
+			return fmt.Errorf("%w: %q", ErrInvalidIndexKey, key)
+		}
+	}
+	return nil
+}
+
 func getOrGenerateIndexName(keySpecDocument bsoncore.Document, model IndexModel) (string, error) {
 	if model.Options != nil && model.Options.Name != nil {
 		return *model.Options.Name, nil
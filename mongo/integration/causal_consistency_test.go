@@ -59,7 +59,7 @@ func TestCausalConsistency_Supported(t *testing.T) {
 		assert.Nil(mt, err, "StartSession error: %v", err)
 		defer sess.EndSession(context.Background())
 
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_, _ = mt.Coll.Find(sc, bson.D{})
 			return nil
 		})
@@ -87,7 +87,7 @@ func TestCausalConsistency_Supported(t *testing.T) {
 				assert.Nil(mt, err, "StartSession error: %v", err)
 				defer sess.EndSession(context.Background())
 
-				_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+				_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 					_ = mt.Coll.FindOne(sc, bson.D{})
 					return nil
 				})
@@ -122,7 +122,7 @@ func TestCausalConsistency_Supported(t *testing.T) {
 				assert.NotNil(mt, currOptime, "expected session operation time, got nil")
 
 				mt.ClearEvents()
-				_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+				_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 					_ = mt.Coll.FindOne(sc, bson.D{})
 					return nil
 				})
@@ -154,13 +154,13 @@ func TestCausalConsistency_Supported(t *testing.T) {
 		assert.Nil(mt, err, "StartSession error: %v", err)
 		defer sess.EndSession(context.Background())
 
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_ = mt.Coll.FindOne(sc, bson.D{})
 			return nil
 		})
 		currOptime := sess.OperationTime()
 		mt.ClearEvents()
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_ = mt.Coll.FindOne(sc, bson.D{})
 			return nil
 		})
@@ -176,13 +176,13 @@ func TestCausalConsistency_Supported(t *testing.T) {
 		assert.Nil(mt, err, "StartSession error: %v", err)
 		defer sess.EndSession(context.Background())
 
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_ = mt.Coll.FindOne(sc, bson.D{})
 			return nil
 		})
 		currOptime := sess.OperationTime()
 		mt.ClearEvents()
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_ = mt.Coll.FindOne(sc, bson.D{})
 			return nil
 		})
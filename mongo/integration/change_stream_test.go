@@ -19,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 )
 
 type resumeType int
@@ -77,6 +78,105 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		want := "$changeStream"
 		assert.Equal(mt, want, firstKey, "expected first stage to be %v, got %v", want, firstKey)
 	})
+	mt.Run("watch view", func(mt *mtest.T) {
+		viewPipeline := mongo.Pipeline{{{"$match", bson.D{{"fullDocument.x", bson.D{{"$gte", 1}}}}}}}
+		err := mt.DB.CreateView(context.Background(), "watchview", mt.Coll.Name(), viewPipeline)
+		assert.Nil(mt, err, "CreateView error: %v", err)
+
+		cs, err := mt.DB.WatchView(context.Background(), "watchview", mongo.Pipeline{})
+		assert.Nil(mt, err, "WatchView error: %v", err)
+		defer closeStream(cs)
+
+		generateEvents(mt, 2)
+		assert.True(mt, cs.Next(context.Background()), "expected next to return true, got false")
+		x := cs.Current.Lookup("fullDocument", "x").Int32()
+		assert.Equal(mt, int32(1), x, "expected x 1, got %v", x)
+
+		_, err = mt.DB.WatchView(context.Background(), mt.Coll.Name(), mongo.Pipeline{})
+		assert.NotNil(mt, err, "expected WatchView error for collection, got nil")
+	})
+	mt.Run("watch collections", func(mt *mtest.T) {
+		other := mt.Client.Database(mt.DB.Name()).Collection(mt.Coll.Name() + "_other")
+		otherDB := mt.Client.Database(mt.DB.Name() + "_other").Collection(mt.Coll.Name())
+		unwatched := mt.Client.Database(mt.DB.Name()).Collection(mt.Coll.Name() + "_unwatched")
+		for _, coll := range []*mongo.Collection{other, otherDB, unwatched} {
+			_, err := coll.InsertOne(context.Background(), bson.D{{"x", 0}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+		}
+		defer func() {
+			_ = other.Drop(context.Background())
+			_ = otherDB.Database().Drop(context.Background())
+			_ = unwatched.Drop(context.Background())
+		}()
+
+		testCases := []struct {
+			name       string
+			namespaces []mongo.Namespace
+			streamed   []*mongo.Collection
+		}{
+			{
+				"same database",
+				[]mongo.Namespace{{mt.DB.Name(), mt.Coll.Name()}, {mt.DB.Name(), other.Name()}},
+				[]*mongo.Collection{mt.Coll, other},
+			},
+			{
+				"different databases",
+				[]mongo.Namespace{{mt.DB.Name(), mt.Coll.Name()}, {otherDB.Database().Name(), otherDB.Name()}},
+				[]*mongo.Collection{mt.Coll, otherDB},
+			},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				cs, err := mongo.WatchCollections(context.Background(), mt.Client, tc.namespaces, mongo.Pipeline{})
+				assert.Nil(mt, err, "WatchCollections error: %v", err)
+				defer closeStream(cs)
+
+				_, err = unwatched.InsertOne(context.Background(), bson.D{{"x", 1}})
+				assert.Nil(mt, err, "InsertOne error: %v", err)
+				for _, coll := range tc.streamed {
+					_, err = coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+					assert.Nil(mt, err, "InsertOne error: %v", err)
+				}
+				for _, coll := range tc.streamed {
+					assert.True(mt, cs.Next(context.Background()), "expected next to return true, got false")
+					got := cs.Current.Lookup("ns", "db").StringValue() + "." + cs.Current.Lookup("ns", "coll").StringValue()
+					assert.Equal(mt, coll.Database().Name()+"."+coll.Name(), got,
+						"expected event on %v, got %v", coll.Name(), got)
+				}
+			})
+		}
+	})
+	mt.RunOpts("auto recreate on invalidate", mtest.NewOptions().MinServerVersion(minStartAfterVersion), func(mt *mtest.T) {
+		var cs *mongo.ChangeStream
+		var recreates int
+		opts := options.ChangeStream().SetAutoRecreateOnInvalidate(func() (bson.Raw, *primitive.Timestamp) {
+			recreates++
+			if recreates > 1 {
+				return nil, nil
+			}
+			// Recreate the collection before the change stream is reopened after the invalidate event.
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", 2}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+			return cs.ResumeToken(), nil
+		})
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+		err = mt.Coll.Drop(context.Background())
+		assert.Nil(mt, err, "Drop error: %v", err)
+
+		for _, expected := range []string{"insert", "drop", "invalidate", "insert"} {
+			assert.True(mt, cs.Next(context.Background()), "expected Next to return true, got false: %v", cs.Err())
+			opType := cs.Current.Lookup("operationType").StringValue()
+			assert.Equal(mt, expected, opType, "expected %v event, got %v", expected, opType)
+		}
+		x := cs.Current.Lookup("fullDocument", "x").Int32()
+		assert.Equal(mt, int32(2), x, "expected x 2 from the recreated collection, got %v", x)
+		assert.Equal(mt, 1, recreates, "expected 1 recreate, got %v", recreates)
+	})
 	mt.Run("track resume token", func(mt *mtest.T) {
 		// ChangeStream must continuously track the last seen resumeToken
 
@@ -638,6 +738,110 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		evt := mt.GetStartedEvent()
 		assert.Equal(mt, "killCursors", evt.CommandName, "expected command 'killCursors', got %q", evt.CommandName)
 	})
+	mt.Run("command monitor", func(mt *mtest.T) {
+		var started []string
+		cm := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = append(started, evt.CommandName)
+			},
+		}
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, options.ChangeStream().SetCommandMonitor(cm))
+		assert.Nil(mt, err, "Watch error: %v", err)
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.M{"x": 1})
+		assert.Nil(mt, err, "InsertOne error: %v", err)
+
+		mt.ClearEvents()
+		assert.True(mt, cs.Next(context.Background()), "Next returned false with error %v", cs.Err())
+		err = cs.Close(context.Background())
+		assert.Nil(mt, err, "Close error: %v", err)
+
+		// More than one getMore may be sent before the event is returned.
+		assert.True(mt, len(started) >= 3, "expected at least 3 commands, got %v", started)
+		assert.Equal(mt, "aggregate", started[0], "expected first command 'aggregate', got %q", started[0])
+		assert.Equal(mt, "getMore", started[1], "expected second command 'getMore', got %q", started[1])
+		last := started[len(started)-1]
+		assert.Equal(mt, "killCursors", last, "expected last command 'killCursors', got %q", last)
+		evt := mt.GetStartedEvent()
+		assert.Nil(mt, evt, "expected no events on the client monitor, got %v", evt)
+	})
+	mt.Run("max docs per getMore", func(mt *mtest.T) {
+		mt.ClearEvents()
+		opts := options.ChangeStream().SetBatchSize(10).SetMaxDocsPerGetMore(2)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+		batchSize := evt.Command.Lookup("cursor", "batchSize").Int32()
+		assert.Equal(mt, int32(10), batchSize, "expected aggregate batchSize 10, got %v", batchSize)
+
+		_, err = mt.Coll.InsertMany(context.Background(), []interface{}{bson.D{{"x", 1}}, bson.D{{"x", 2}}, bson.D{{"x", 3}}})
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+		mt.ClearEvents()
+		assert.True(mt, cs.Next(context.Background()), "Next returned false with error %v", cs.Err())
+
+		evt = mt.GetStartedEvent()
+		assert.Equal(mt, "getMore", evt.CommandName, "expected command 'getMore', got %q", evt.CommandName)
+		batchSize = evt.Command.Lookup("batchSize").Int32()
+		assert.Equal(mt, int32(2), batchSize, "expected getMore batchSize 2, got %v", batchSize)
+		succeeded := mt.GetSucceededEvent()
+		docs, err := succeeded.Reply.Lookup("cursor", "nextBatch").Array().Values()
+		assert.Nil(mt, err, "Values error: %v", err)
+		numDocs := len(docs)
+		assert.True(mt, numDocs <= 2, "expected at most 2 documents in getMore batch, got %v", numDocs)
+	})
+	mt.Run("zero batch size", func(mt *mtest.T) {
+		// Resume after the first of two events so the server has an event it could return in the first batch.
+		first, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(first)
+		generateEvents(mt, 2)
+		assert.True(mt, first.Next(context.Background()), "Next returned false with error %v", first.Err())
+
+		mt.ClearEvents()
+		opts := options.ChangeStream().SetBatchSize(0).SetResumeAfter(first.ResumeToken())
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+		batchSize := evt.Command.Lookup("cursor", "batchSize").Int32()
+		assert.Equal(mt, int32(0), batchSize, "expected aggregate batchSize 0, got %v", batchSize)
+		succeeded := mt.GetSucceededEvent()
+		docs, err := succeeded.Reply.Lookup("cursor", "firstBatch").Array().Values()
+		assert.Nil(mt, err, "Values error: %v", err)
+		assert.Equal(mt, 0, len(docs), "expected empty first batch, got %v documents", len(docs))
+		assert.True(mt, cs.ID() > 0, "expected non-zero ID, got 0")
+
+		assert.True(mt, cs.Next(context.Background()), "Next returned false with error %v", cs.Err())
+		evt = mt.GetStartedEvent()
+		assert.Equal(mt, "getMore", evt.CommandName, "expected command 'getMore', got %q", evt.CommandName)
+		_, err = evt.Command.LookupErr("batchSize")
+		assert.NotNil(mt, err, "expected batchSize to be omitted from getMore %v", evt.Command)
+	})
+	mt.Run("read concern", func(mt *mtest.T) {
+		coll, err := mt.Coll.Clone(options.Collection().SetReadConcern(readconcern.Local()))
+		assert.Nil(mt, err, "Clone error: %v", err)
+
+		mt.ClearEvents()
+		opts := options.ChangeStream().SetReadConcern(readconcern.Majority())
+		cs, err := coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+		level, err := evt.Command.LookupErr("readConcern", "level")
+		assert.Nil(mt, err, "expected readConcern level in command %v", evt.Command)
+		assert.Equal(mt, "majority", level.StringValue(), "expected level 'majority', got %v", level)
+
+		_, err = coll.Watch(context.Background(), mongo.Pipeline{},
+			options.ChangeStream().SetReadConcern(readconcern.Snapshot()))
+		assert.NotNil(mt, err, "expected Watch error with snapshot read concern, got nil")
+	})
 	mt.Run("Custom", func(mt *mtest.T) {
 		// Custom options should be a BSON map of option names to Marshalable option values.
 		// We use "allowDiskUse" as an example.
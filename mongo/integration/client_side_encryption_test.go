@@ -403,6 +403,57 @@ func TestClientSideEncryptionCustomCrypt(t *testing.T) {
 	})
 }
 
+func TestClientEncryptionEncryptMany(t *testing.T) {
+	verifyClientSideEncryptionVarsSet(t)
+	mt := mtest.New(t, mtest.NewOptions().MinServerVersion("4.2").Enterprise(true).CreateClient(false))
+	defer mt.Close()
+
+	kmsProvidersMap := map[string]map[string]interface{}{
+		"local": {"key": localMasterKey},
+	}
+
+	mt.Run("encrypts values in order", func(mt *mtest.T) {
+		kvClientOpts := options.Client().ApplyURI(mtest.ClusterURI())
+		testutil.AddTestServerAPIVersion(kvClientOpts)
+		kvClient, err := mongo.Connect(context.Background(), kvClientOpts)
+		assert.Nil(mt, err, "Connect error: %v", err)
+		defer kvClient.Disconnect(context.Background())
+
+		err = kvClient.Database("keyvault").Collection("datakeys").Drop(context.Background())
+		assert.Nil(mt, err, "Drop error: %v", err)
+
+		ceOpts := options.ClientEncryption().
+			SetKmsProviders(kmsProvidersMap).
+			SetKeyVaultNamespace("keyvault.datakeys")
+		ce, err := mongo.NewClientEncryption(kvClient, ceOpts)
+		assert.Nil(mt, err, "NewClientEncryption error: %v", err)
+		defer ce.Close(context.Background())
+
+		_, err = ce.CreateDataKey(context.Background(), "local", options.DataKey().SetKeyAltNames([]string{"myKey"}))
+		assert.Nil(mt, err, "CreateDataKey error: %v", err)
+
+		vals := []bson.RawValue{
+			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "foo")},
+			{Type: bsontype.Int32, Value: bsoncore.AppendInt32(nil, 42)},
+			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "bar")},
+		}
+		eOpts := options.Encrypt().
+			SetAlgorithm("AEAD_AES_256_CBC_HMAC_SHA_512-Deterministic").
+			SetKeyAltName("myKey")
+		encrypted, err := ce.EncryptMany(context.Background(), vals, eOpts)
+		assert.Nil(mt, err, "EncryptMany error: %v", err)
+		assert.Equal(mt, len(vals), len(encrypted), "expected %v encrypted values, got %v", len(vals), len(encrypted))
+
+		for i, ciphertext := range encrypted {
+			subtype, data := ciphertext.Binary()
+			assert.Equal(mt, byte(6), subtype, "expected binary subtype 6, got %v", subtype)
+			decrypted, err := ce.Decrypt(context.Background(), primitive.Binary{Subtype: subtype, Data: data})
+			assert.Nil(mt, err, "Decrypt error: %v", err)
+			assert.True(mt, vals[i].Equal(decrypted), "expected value %v at index %d, got %v", vals[i], i, decrypted)
+		}
+	})
+}
+
 func TestFLE2CreateCollection(t *testing.T) {
 	// FLE 2 (aka Queryable Encryption) is not supported on Standalone topology.
 	mtOpts := mtest.NewOptions().
@@ -8,6 +8,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -175,6 +176,18 @@ func TestCollection(t *testing.T) {
 			evt = mt.GetStartedEvent()
 			assert.Equal(mt, "insert", evt.CommandName, "expected 'insert' event, got '%v'", evt.CommandName)
 		})
+		mt.Run("progress", func(mt *mtest.T) {
+			mt.Parallel()
+
+			var progress []int
+			docs := []interface{}{create16MBDocument(mt), create16MBDocument(mt)}
+			res, err := mt.Coll.InsertManyWithProgress(context.Background(), docs, func(inserted int) {
+				progress = append(progress, inserted)
+			})
+			assert.Nil(mt, err, "InsertManyWithProgress error: %v", err)
+			assert.Equal(mt, 2, len(res.InsertedIDs), "expected 2 inserted IDs, got %v", len(res.InsertedIDs))
+			assert.Equal(mt, []int{1, 2}, progress, "expected progress %v, got %v", []int{1, 2}, progress)
+		})
 		mt.RunOpts("write error", noClientOpts, func(mt *mtest.T) {
 			mt.Parallel()
 
@@ -282,6 +295,36 @@ func TestCollection(t *testing.T) {
 			assert.NotNil(mt, we.WriteConcernError, "expected write concern error, got %+v", err)
 		})
 	})
+	mt.RunOpts("insert many with IDs", noClientOpts, func(mt *mtest.T) {
+		mt.Run("success", func(mt *mtest.T) {
+			docs := []interface{}{
+				bson.D{{"_id", int32(11)}},
+				bson.D{{"x", 6}},
+			}
+
+			ids, err := mt.Coll.InsertManyWithIDs(context.Background(), docs)
+			assert.Nil(mt, err, "InsertManyWithIDs error: %v", err)
+			assert.Equal(mt, 2, len(ids), "expected 2 IDs, got %v", len(ids))
+			assert.Equal(mt, int32(11), ids[0], "expected ID %v, got %v", int32(11), ids[0])
+			oid, ok := ids[1].(primitive.ObjectID)
+			assert.True(mt, ok, "expected ID type %T, got %T", primitive.ObjectID{}, ids[1])
+
+			err = mt.Coll.FindOne(context.Background(), bson.D{{"_id", oid}}).Err()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+		})
+		mt.Run("IDs returned on write error", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			ids, err := mt.Coll.InsertManyWithIDs(context.Background(), []interface{}{bson.D{{"x", 1}}, bson.D{{"_id", 1}}})
+			_, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %T, got %T", mongo.BulkWriteException{}, err)
+			assert.Equal(mt, 2, len(ids), "expected 2 IDs, got %v", len(ids))
+
+			err = mt.Coll.FindOne(context.Background(), bson.D{{"_id", ids[0]}}).Err()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+		})
+	})
 	mt.RunOpts("delete one", noClientOpts, func(mt *mtest.T) {
 		mt.Run("found", func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
@@ -601,6 +644,27 @@ func TestCollection(t *testing.T) {
 			assert.True(mt, ok, "expected error type %v, got %v", mongo.WriteException{}, err)
 			assert.NotNil(mt, we.WriteConcernError, "expected write concern error, got %+v", we)
 		})
+		mt.Run("replace doc", func(mt *mtest.T) {
+			id := primitive.NewObjectID()
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", id}, {"x", int32(1)}, {"y", int32(1)}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			opts := options.Update().SetReplaceDoc(true)
+			res, err := mt.Coll.UpdateByID(context.Background(), id, bson.D{{"$inc", bson.D{{"x", 1}}}}, opts)
+			assert.Nil(mt, err, "UpdateByID error: %v", err)
+			assert.Equal(mt, int64(1), res.ModifiedCount, "expected modified count 1, got %v", res.ModifiedCount)
+
+			res, err = mt.Coll.UpdateByID(context.Background(), id, bson.D{{"z", int32(3)}}, opts)
+			assert.Nil(mt, err, "UpdateByID error: %v", err)
+			assert.Equal(mt, int64(1), res.ModifiedCount, "expected modified count 1, got %v", res.ModifiedCount)
+
+			got, err := mt.Coll.FindOne(context.Background(), bson.D{{"_id", id}}).DecodeBytes()
+			assert.Nil(mt, err, "FindOne error: %v", err)
+			want := bson.D{{"_id", id}, {"z", int32(3)}}
+			wantBytes, err := bson.Marshal(want)
+			assert.Nil(mt, err, "Marshal error: %v", err)
+			assert.Equal(mt, bson.Raw(wantBytes), got, "expected document %v, got %v", want, got)
+		})
 	})
 	mt.RunOpts("update many", noClientOpts, func(mt *mtest.T) {
 		mt.Run("empty update", func(mt *mtest.T) {
@@ -763,6 +827,20 @@ func TestCollection(t *testing.T) {
 		mt.Run("options", func(mt *mtest.T) {
 			testAggregateWithOptions(mt, false, options.Aggregate().SetAllowDiskUse(true))
 		})
+		mt.RunOpts("comment", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+			mt.ClearEvents()
+
+			comment := "aggregate comment"
+			_, err := mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, options.Aggregate().SetComment(comment))
+			assert.Nil(mt, err, "Aggregate error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.Equal(mt, "aggregate", evt.CommandName, "expected 'aggregate' event, got '%v'", evt.CommandName)
+			got, ok := evt.Command.Lookup("comment").StringValueOK()
+			assert.True(mt, ok, "expected comment in command %v", evt.Command)
+			assert.Equal(mt, comment, got, "expected comment %q, got %q", comment, got)
+		})
 		mt.RunOpts("single key map hint", mtest.NewOptions().MinServerVersion("3.6"), func(mt *mtest.T) {
 			hint := bson.M{"x": 1}
 			testAggregateWithOptions(mt, true, options.Aggregate().SetHint(hint))
@@ -794,6 +872,39 @@ func TestCollection(t *testing.T) {
 				return mt.Coll.Aggregate(context.Background(), mongo.Pipeline{}, options.Aggregate().SetBatchSize(3))
 			})
 		})
+		mt.Run("batch size", func(mt *mtest.T) {
+			outPipeline := mongo.Pipeline{{{"$out", mt.Coll.Name() + "_out"}}}
+			testCases := []struct {
+				name         string
+				pipeline     mongo.Pipeline
+				opts         *options.AggregateOptions
+				hasBatchSize bool
+				batchSize    int32
+			}{
+				{"not set", mongo.Pipeline{}, options.Aggregate(), false, 0},
+				{"zero", mongo.Pipeline{}, options.Aggregate().SetBatchSize(0), true, 0},
+				{"one", mongo.Pipeline{}, options.Aggregate().SetBatchSize(1), true, 1},
+				{"zero with output stage", outPipeline, options.Aggregate().SetBatchSize(0), false, 0},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					mt.ClearEvents()
+					cursor, err := mt.Coll.Aggregate(context.Background(), tc.pipeline, tc.opts)
+					assert.Nil(mt, err, "Aggregate error: %v", err)
+					_ = cursor.Close(context.Background())
+
+					evt := mt.GetStartedEvent()
+					assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+					val, err := evt.Command.LookupErr("cursor", "batchSize")
+					if !tc.hasBatchSize {
+						assert.NotNil(mt, err, "expected no batchSize in command %v", evt.Command)
+						return
+					}
+					assert.Nil(mt, err, "expected batchSize in command %v", evt.Command)
+					assert.Equal(mt, tc.batchSize, val.Int32(), "expected batchSize %v, got %v", tc.batchSize, val)
+				})
+			}
+		})
 		mt.Run("Custom", func(mt *mtest.T) {
 			// Custom options should be a BSON map of option names to Marshalable option values.
 			// We use "allowDiskUse" as an example.
@@ -853,6 +964,34 @@ func TestCollection(t *testing.T) {
 			assert.Equal(mt, mongo.ErrMapForOrderedArgument{"hint"}, err, "expected error %v, got %v", mongo.ErrMapForOrderedArgument{"hint"}, err)
 		})
 	})
+	mt.Run("count by field", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		docs := []interface{}{
+			bson.D{{"x", 2}, {"y", bson.D{{"a", 1}}}},
+			bson.D{{"x", 7}, {"y", bson.D{{"a", 1}}}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		testCases := []struct {
+			name      string
+			fieldName string
+			value     interface{}
+			opts      *options.CountOptions
+			count     int64
+		}{
+			{"value", "x", 2, nil, 2},
+			{"no match", "x", 10, nil, 0},
+			{"document value", "y", bson.D{{"a", 1}}, nil, 2},
+			{"dot notation", "y.a", 1, nil, 2},
+			{"options", "x", 2, options.Count().SetLimit(1), 1},
+		}
+		for _, tc := range testCases {
+			count, err := mt.Coll.CountByField(context.Background(), tc.fieldName, tc.value, tc.opts)
+			assert.Nil(mt, err, "CountByField error for %v: %v", tc.name, err)
+			assert.Equal(mt, tc.count, count, "expected count %v for %v, got %v", tc.count, tc.name, count)
+		}
+	})
 	mt.RunOpts("estimated document count", noClientOpts, func(mt *mtest.T) {
 		testCases := []struct {
 			name  string
@@ -871,6 +1010,37 @@ func TestCollection(t *testing.T) {
 			})
 		}
 	})
+	mt.RunOpts("count documents estimated", noClientOpts, func(mt *mtest.T) {
+		testCases := []struct {
+			name    string
+			filter  interface{}
+			maxMs   int64
+			opts    *options.CountOptions
+			count   int64
+			cmdName string
+		}{
+			{"nil filter", nil, 0, nil, 5, "count"},
+			{"nil filter with limit", nil, 0, options.Count().SetLimit(3), 3, "aggregate"},
+			{"filter", bson.D{{"x", bson.D{{"$gt", 2}}}}, 1000, nil, 3, "aggregate"},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				initCollection(mt, mt.Coll)
+				mt.ClearEvents()
+				count, err := mt.Coll.CountDocumentsEstimated(context.Background(), tc.filter, tc.maxMs, tc.opts)
+				assert.Nil(mt, err, "CountDocumentsEstimated error: %v", err)
+				assert.Equal(mt, tc.count, count, "expected count %v, got %v", tc.count, count)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, tc.cmdName, evt.CommandName, "expected command %q, got %q", tc.cmdName, evt.CommandName)
+				if tc.maxMs > 0 {
+					maxTimeMS, err := evt.Command.LookupErr("maxTimeMS")
+					assert.Nil(mt, err, "expected maxTimeMS in command %v", evt.Command)
+					assert.Equal(mt, tc.maxMs, maxTimeMS.Int64(), "expected maxTimeMS %v, got %v", tc.maxMs, maxTimeMS)
+				}
+			})
+		}
+	})
 	mt.RunOpts("distinct", noClientOpts, func(mt *mtest.T) {
 		all := []interface{}{int32(1), int32(2), int32(3), int32(4), int32(5)}
 		last3 := []interface{}{int32(3), int32(4), int32(5)}
@@ -893,6 +1063,238 @@ func TestCollection(t *testing.T) {
 			})
 		}
 	})
+	mt.RunOpts("distinct with collation", mtest.NewOptions().MinServerVersion("3.4"), func(mt *mtest.T) {
+		docs := []interface{}{bson.D{{"x", "a"}}, bson.D{{"x", "A"}}, bson.D{{"x", "b"}}}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		collation := &options.Collation{Locale: "en_US", Strength: 2}
+		res, err := mt.Coll.DistinctWithCollation(context.Background(), "x", bson.D{}, collation)
+		assert.Nil(mt, err, "DistinctWithCollation error: %v", err)
+		assert.Equal(mt, 2, len(res), "expected 2 distinct values, got %v", res)
+	})
+	mt.RunOpts("compact", mtest.NewOptions().Topologies(mtest.Single).MinServerVersion("4.4"), func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		mt.ClearEvents()
+
+		opts := options.Compact().SetForce(true).SetFreeSpaceTargetMB(1).SetComment("compact comment")
+		res, err := mt.Coll.Compact(context.Background(), opts)
+		assert.Nil(mt, err, "Compact error: %v", err)
+		assert.True(mt, res.BytesFreed >= 0, "expected non-negative bytesFreed, got %v", res.BytesFreed)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "compact", evt.CommandName, "expected 'compact' event, got '%v'", evt.CommandName)
+		assert.True(mt, evt.Command.Lookup("force").Boolean(), "expected force true in command %v", evt.Command)
+		assert.Equal(mt, int64(1), evt.Command.Lookup("freeSpaceTargetMB").Int64(),
+			"expected freeSpaceTargetMB 1 in command %v", evt.Command)
+		assert.Equal(mt, "compact comment", evt.Command.Lookup("comment").StringValue(),
+			"expected comment in command %v", evt.Command)
+	})
+	mt.Run("distinct pipeline", func(mt *mtest.T) {
+		docs := []interface{}{
+			bson.D{{"tags", bson.A{"a", "b"}}},
+			bson.D{{"tags", bson.A{"b", "c"}}},
+			bson.D{{"tags", bson.A{"c"}}},
+		}
+		_, err := mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		pipeline := mongo.Pipeline{{{"$unwind", "$tags"}}, {{"$sort", bson.D{{"tags", 1}}}}}
+		res, err := mt.Coll.DistinctPipeline(context.Background(), "tags", pipeline)
+		assert.Nil(mt, err, "DistinctPipeline error: %v", err)
+		assert.Equal(mt, 3, len(res), "expected 3 distinct values, got %v", res)
+		for _, tag := range []string{"a", "b", "c"} {
+			var found bool
+			for _, val := range res {
+				found = found || val == tag
+			}
+			assert.True(mt, found, "expected %q in distinct values %v", tag, res)
+		}
+
+		_, err = mt.Coll.DistinctPipeline(context.Background(), "", pipeline)
+		assert.NotNil(mt, err, "expected error for empty field name, got nil")
+	})
+	mt.Run("paginated find", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+
+		var xs []int32
+		var cursor *primitive.ObjectID
+		for {
+			page, err := mt.Coll.PaginatedFind(context.Background(), bson.D{{"x", bson.D{{"$gt", 1}}}}, 2, cursor, nil)
+			assert.Nil(mt, err, "PaginatedFind error: %v", err)
+			for _, doc := range page.Docs {
+				xs = append(xs, doc.Lookup("x").Int32())
+			}
+			if !page.HasMore {
+				assert.Equal(mt, 1, len(page.Docs), "expected 1 document in last page, got %v", len(page.Docs))
+				break
+			}
+			assert.Equal(mt, 2, len(page.Docs), "expected 2 documents, got %v", len(page.Docs))
+			cursor = page.NextCursor
+		}
+		expected := []int32{2, 3, 4, 5}
+		assert.Equal(mt, expected, xs, "expected documents %v, got %v", expected, xs)
+
+		page, err := mt.Coll.PaginatedFind(context.Background(), bson.D{}, 10, nil, bson.D{{"_id", -1}})
+		assert.Nil(mt, err, "PaginatedFind error: %v", err)
+		assert.False(mt, page.HasMore, "expected no more pages")
+		first := page.Docs[0].Lookup("x").Int32()
+		assert.Equal(mt, int32(5), first, "expected first document to have x 5, got %v", first)
+	})
+	mt.Run("find and iterate", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+
+		var cursor *mongo.Cursor
+		var count int
+		err := mt.Coll.FindAndIterate(context.Background(), bson.D{}, func(cur *mongo.Cursor) error {
+			cursor = cur
+			for cur.Next(context.Background()) {
+				count++
+			}
+			return cur.Err()
+		}, options.Find().SetBatchSize(2))
+		assert.Nil(mt, err, "FindAndIterate error: %v", err)
+		assert.Equal(mt, 5, count, "expected 5 documents, got %v", count)
+		assert.Equal(mt, int64(0), cursor.ID(), "expected cursor to be closed, got ID %v", cursor.ID())
+
+		fnErr := errors.New("iteration error")
+		err = mt.Coll.FindAndIterate(context.Background(), bson.D{}, func(cur *mongo.Cursor) error {
+			cursor = cur
+			return fnErr
+		}, options.Find().SetBatchSize(2))
+		assert.Equal(mt, fnErr, err, "expected error %v, got %v", fnErr, err)
+		assert.Equal(mt, int64(0), cursor.ID(), "expected cursor to be closed, got ID %v", cursor.ID())
+	})
+	mt.Run("create TTL index", func(mt *mtest.T) {
+		name, err := mt.Coll.CreateTTLIndex(context.Background(), "createdAt", 90*time.Minute+500*time.Millisecond)
+		assert.Nil(mt, err, "CreateTTLIndex error: %v", err)
+		assert.Equal(mt, "createdAt_1", name, "expected index name createdAt_1, got %v", name)
+
+		specs, err := mt.Coll.Indexes().ListSpecifications(context.Background())
+		assert.Nil(mt, err, "ListSpecifications error: %v", err)
+		var found bool
+		for _, spec := range specs {
+			if spec.Name != name {
+				continue
+			}
+			found = true
+			assert.NotNil(mt, spec.ExpireAfterSeconds, "expected expireAfterSeconds to be set")
+			assert.Equal(mt, int32(5400), *spec.ExpireAfterSeconds, "expected expireAfterSeconds 5400, got %v",
+				*spec.ExpireAfterSeconds)
+		}
+		assert.True(mt, found, "expected index %v in specifications %v", name, specs)
+	})
+	mt.Run("validate", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		mt.ClearEvents()
+
+		res, err := mt.Coll.Validate(context.Background(), options.Validate().SetFull(true))
+		assert.Nil(mt, err, "Validate error: %v", err)
+		assert.True(mt, res.Valid, "expected collection to be valid, got errors %v", res.Errors)
+		assert.Equal(mt, mt.Coll.Namespace(), res.Ns, "expected namespace %v, got %v", mt.Coll.Namespace(), res.Ns)
+		assert.Equal(mt, int64(5), res.Nrecords, "expected 5 records, got %v", res.Nrecords)
+		assert.Equal(mt, int64(1), res.Nindexes, "expected 1 index, got %v", res.Nindexes)
+		assert.Equal(mt, int64(5), res.KeysPerIndex["_id_"], "expected 5 keys in _id_ index, got %v", res.KeysPerIndex)
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "validate", evt.CommandName, "expected 'validate' event, got '%v'", evt.CommandName)
+		assert.True(mt, evt.Command.Lookup("full").Boolean(), "expected full true in command %v", evt.Command)
+	})
+	mt.Run("find one with hint", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"x", 1}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+
+		testCases := []struct {
+			name string
+			hint interface{}
+		}{
+			{"index name", "x_1"},
+			{"index specification", bson.D{{"x", 1}}},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				mt.ClearEvents()
+				opts := options.FindOne().SetHint("_id_").SetSort(bson.D{{"x", -1}})
+				res, err := mt.Coll.FindOneWithHint(context.Background(), bson.D{}, tc.hint, opts).DecodeBytes()
+				assert.Nil(mt, err, "FindOneWithHint error: %v", err)
+				x := res.Lookup("x").Int32()
+				assert.Equal(mt, int32(5), x, "expected x 5, got %v", x)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "find", evt.CommandName, "expected 'find' event, got '%v'", evt.CommandName)
+				hint := evt.Command.Lookup("hint")
+				expected, err := bson.Marshal(bson.D{{"hint", tc.hint}})
+				assert.Nil(mt, err, "Marshal error: %v", err)
+				assert.True(mt, hint.Equal(bson.Raw(expected).Lookup("hint")), "expected hint %v, got %v", tc.hint, hint)
+			})
+		}
+	})
+	mt.Run("set validator", func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+
+		validator, err := mt.Coll.GetValidator(context.Background())
+		assert.Nil(mt, err, "GetValidator error: %v", err)
+		assert.Nil(mt, validator, "expected no validator, got %v", validator)
+
+		schema := bson.D{
+			{"bsonType", "object"},
+			{"required", bson.A{"x"}},
+			{"properties", bson.D{{"x", bson.D{{"bsonType", "int"}}}}},
+		}
+		err = mt.Coll.SetValidator(context.Background(), bson.D{{"$jsonSchema", schema}}, "strict", "error")
+		assert.Nil(mt, err, "SetValidator error: %v", err)
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"y", 1}})
+		assert.NotNil(mt, err, "expected InsertOne error for document without x, got nil")
+
+		validator, err = mt.Coll.GetValidator(context.Background())
+		assert.Nil(mt, err, "GetValidator error: %v", err)
+		required := validator.Lookup("$jsonSchema", "required").Array().Index(0).Value().StringValue()
+		assert.Equal(mt, "x", required, "expected required field x, got %v", required)
+
+		// The retrieved validator can be set again as is.
+		err = mt.Coll.SetValidator(context.Background(), validator, "moderate", "")
+		assert.Nil(mt, err, "SetValidator error: %v", err)
+
+		err = mt.Coll.SetValidator(context.Background(), bson.D{}, "", "")
+		assert.Nil(mt, err, "SetValidator error: %v", err)
+		validator, err = mt.Coll.GetValidator(context.Background())
+		assert.Nil(mt, err, "GetValidator error: %v", err)
+		assert.Nil(mt, validator, "expected no validator after removing it, got %v", validator)
+	})
+	mt.Run("text search", func(mt *mtest.T) {
+		_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{Keys: bson.D{{"text", "text"}}})
+		assert.Nil(mt, err, "CreateOne error: %v", err)
+		docs := []interface{}{
+			bson.D{{"_id", 1}, {"text", "coffee shop"}},
+			bson.D{{"_id", 2}, {"text", "tea shop"}},
+			bson.D{{"_id", 3}, {"text", "coffee and cake"}},
+		}
+		_, err = mt.Coll.InsertMany(context.Background(), docs)
+		assert.Nil(mt, err, "InsertMany error: %v", err)
+
+		cursor, err := mt.Coll.TextSearch(context.Background(), "coffee", "", options.Find().SetSort(bson.D{{"_id", 1}}))
+		assert.Nil(mt, err, "TextSearch error: %v", err)
+		var results []bson.Raw
+		err = cursor.All(context.Background(), &results)
+		assert.Nil(mt, err, "All error: %v", err)
+		assert.Equal(mt, 2, len(results), "expected 2 results, got %v", len(results))
+		for _, res := range results {
+			_, ok := res.Lookup("score").DoubleOK()
+			assert.True(mt, ok, "expected score field in %v", res)
+		}
+
+		cursor, err = mt.Coll.TextSearch(context.Background(), "shop", "english",
+			options.Find().SetProjection(bson.D{{"_id", 1}}))
+		assert.Nil(mt, err, "TextSearch error: %v", err)
+		results = nil
+		err = cursor.All(context.Background(), &results)
+		assert.Nil(mt, err, "All error: %v", err)
+		assert.Equal(mt, 2, len(results), "expected 2 results, got %v", len(results))
+		_, err = results[0].LookupErr("score")
+		assert.NotNil(mt, err, "expected score field to be omitted from %v", results[0])
+	})
 	mt.RunOpts("find", noClientOpts, func(mt *mtest.T) {
 		mt.Run("found", func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
@@ -1229,6 +1631,58 @@ func TestCollection(t *testing.T) {
 			}
 		})
 	})
+	mt.RunOpts("insert or find", noClientOpts, func(mt *mtest.T) {
+		inserted, res, err := mt.Coll.InsertOrFind(context.Background(), bson.D{{"_id", 1}, {"x", 1}})
+		assert.Nil(mt, err, "InsertOrFind error: %v", err)
+		assert.True(mt, inserted, "expected document to be inserted")
+		x, err := res.DecodeBytes()
+		assert.Nil(mt, err, "DecodeBytes error: %v", err)
+		assert.Equal(mt, int32(1), x.Lookup("x").Int32(), "expected x 1, got %v", x.Lookup("x"))
+
+		inserted, res, err = mt.Coll.InsertOrFind(context.Background(), bson.D{{"_id", 1}, {"x", 2}})
+		assert.Nil(mt, err, "InsertOrFind error: %v", err)
+		assert.False(mt, inserted, "expected existing document to be found")
+		x, err = res.DecodeBytes()
+		assert.Nil(mt, err, "DecodeBytes error: %v", err)
+		assert.Equal(mt, int32(1), x.Lookup("x").Int32(), "expected x 1, got %v", x.Lookup("x"))
+	})
+	mt.RunOpts("find by id", noClientOpts, func(mt *mtest.T) {
+		mt.Run("nil id", func(mt *mtest.T) {
+			var res bson.D
+			err := mt.Coll.FindByID(context.Background(), nil, &res)
+			assert.Equal(mt, mongo.ErrNilValue, err, "expected error %v, got %v", mongo.ErrNilValue, err)
+		})
+		mt.Run("found", func(mt *mtest.T) {
+			testCases := []struct {
+				name string
+				id   interface{}
+			}{
+				{"objectID", primitive.NewObjectID()},
+				{"string", "foo"},
+				{"int", int32(11)},
+			}
+			for _, tc := range testCases {
+				mt.Run(tc.name, func(mt *mtest.T) {
+					_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", tc.id}, {"x", int32(1)}})
+					assert.Nil(mt, err, "InsertOne error: %v", err)
+
+					var res struct {
+						ID interface{} `bson:"_id"`
+						X  int32       `bson:"x"`
+					}
+					err = mt.Coll.FindByID(context.Background(), tc.id, &res)
+					assert.Nil(mt, err, "FindByID error: %v", err)
+					assert.Equal(mt, tc.id, res.ID, "expected _id %v, got %v", tc.id, res.ID)
+					assert.Equal(mt, int32(1), res.X, "expected x 1, got %v", res.X)
+				})
+			}
+		})
+		mt.Run("not found", func(mt *mtest.T) {
+			var res bson.D
+			err := mt.Coll.FindByID(context.Background(), primitive.NewObjectID(), &res)
+			assert.Equal(mt, mongo.ErrNoDocuments, err, "expected error %v, got %v", mongo.ErrNoDocuments, err)
+		})
+	})
 	mt.RunOpts("find one and delete", noClientOpts, func(mt *mtest.T) {
 		mt.Run("found", func(mt *mtest.T) {
 			initCollection(mt, mt.Coll)
@@ -1859,6 +2313,43 @@ func TestCollection(t *testing.T) {
 			}
 		})
 	})
+	mt.RunOpts("bulk write with result", noClientOpts, func(mt *mtest.T) {
+		models := []mongo.WriteModel{
+			mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 1}, {"x", 1}}),
+			mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 1}}),
+			mongo.NewUpdateOneModel().SetFilter(bson.D{{"_id", 1}}).SetUpdate(bson.D{{"$inc", bson.D{{"x", 1}}}}),
+			mongo.NewReplaceOneModel().SetFilter(bson.D{{"_id", 2}}).SetReplacement(bson.D{{"x", 1}}).SetUpsert(true),
+			mongo.NewDeleteManyModel().SetFilter(bson.D{}),
+		}
+
+		mt.Run("unordered", func(mt *mtest.T) {
+			res, err := mt.Coll.BulkWriteWithResult(context.Background(), models, options.BulkWrite().SetOrdered(false))
+			bwe, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %T, got %v", mongo.BulkWriteException{}, err)
+			assert.Equal(mt, 1, len(bwe.WriteErrors), "expected 1 write error, got %v", len(bwe.WriteErrors))
+			assert.Equal(mt, 1, bwe.WriteErrors[0].Index, "expected index 1, got %v", bwe.WriteErrors[0].Index)
+
+			assert.Equal(mt, len(models), len(res.Results), "expected %v results, got %v", len(models), len(res.Results))
+			assert.Equal(mt, int64(1), res.Results[0].InsertedCount, "expected InsertedCount 1, got %v",
+				res.Results[0].InsertedCount)
+			assert.NotNil(mt, res.Results[1].Error, "expected error for duplicate insert, got nil")
+			assert.Equal(mt, int64(1), res.Results[2].MatchedCount, "expected MatchedCount 1, got %v",
+				res.Results[2].MatchedCount)
+			assert.Equal(mt, int64(1), res.Results[2].ModifiedCount, "expected ModifiedCount 1, got %v",
+				res.Results[2].ModifiedCount)
+			assert.Equal(mt, int32(2), res.Results[3].UpsertedID, "expected UpsertedID 2, got %v",
+				res.Results[3].UpsertedID)
+			assert.Equal(mt, int64(2), res.Results[4].DeletedCount, "expected DeletedCount 2, got %v",
+				res.Results[4].DeletedCount)
+		})
+		mt.Run("ordered", func(mt *mtest.T) {
+			res, err := mt.Coll.BulkWriteWithResult(context.Background(), models)
+			_, ok := err.(mongo.BulkWriteException)
+			assert.True(mt, ok, "expected error type %T, got %v", mongo.BulkWriteException{}, err)
+			assert.Equal(mt, 2, len(res.Results), "expected 2 results, got %v", len(res.Results))
+			assert.NotNil(mt, res.Results[1].Error, "expected error for duplicate insert, got nil")
+		})
+	})
 }
 
 func initCollection(mt *mtest.T, coll *mongo.Collection) {
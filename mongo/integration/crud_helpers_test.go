@@ -189,7 +189,7 @@ func executeAggregate(mt *mtest.T, agg aggregator, sess mongo.Session, args bson
 
 	if sess != nil {
 		var cur *mongo.Cursor
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var aerr error
 			cur, aerr = agg.Aggregate(sc, pipeline, opts)
 			return aerr
@@ -218,7 +218,7 @@ func executeWatch(mt *mtest.T, w watcher, sess mongo.Session, args bson.Raw) (*m
 
 	if sess != nil {
 		var stream *mongo.ChangeStream
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var csErr error
 			stream, csErr = w.Watch(sc, pipeline)
 			return csErr
@@ -256,7 +256,7 @@ func executeCountDocuments(mt *mtest.T, sess mongo.Session, args bson.Raw) (int6
 
 	if sess != nil {
 		var count int64
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var countErr error
 			count, countErr = mt.Coll.CountDocuments(sc, filter, opts)
 			return countErr
@@ -290,7 +290,7 @@ func executeInsertOne(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.In
 
 	if sess != nil {
 		var res *mongo.InsertOneResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var insertErr error
 			res, insertErr = mt.Coll.InsertOne(sc, doc, opts)
 			return insertErr
@@ -328,7 +328,7 @@ func executeInsertMany(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.I
 
 	if sess != nil {
 		var res *mongo.InsertManyResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var insertErr error
 			res, insertErr = mt.Coll.InsertMany(sc, docs, opts)
 			return insertErr
@@ -401,7 +401,7 @@ func executeFind(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.Cursor,
 
 	if sess != nil {
 		var c *mongo.Cursor
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var findErr error
 			c, findErr = mt.Coll.Find(sc, filter, opts)
 			return findErr
@@ -435,7 +435,7 @@ func executeRunCommand(mt *mtest.T, sess mongo.Session, args bson.Raw) *mongo.Si
 
 	if sess != nil {
 		var sr *mongo.SingleResult
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			sr = mt.DB.RunCommand(sc, cmd, opts)
 			return nil
 		})
@@ -463,7 +463,7 @@ func executeListCollections(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mo
 
 	if sess != nil {
 		var c *mongo.Cursor
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var lcErr error
 			c, lcErr = mt.DB.ListCollections(sc, filter)
 			return lcErr
@@ -492,7 +492,7 @@ func executeListCollectionNames(mt *mtest.T, sess mongo.Session, args bson.Raw)
 
 	if sess != nil {
 		var res []string
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var lcErr error
 			res, lcErr = mt.DB.ListCollectionNames(sc, filter)
 			return lcErr
@@ -521,7 +521,7 @@ func executeListDatabaseNames(mt *mtest.T, sess mongo.Session, args bson.Raw) ([
 
 	if sess != nil {
 		var res []string
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var ldErr error
 			res, ldErr = mt.Client.ListDatabaseNames(sc, filter)
 			return ldErr
@@ -550,7 +550,7 @@ func executeListDatabases(mt *mtest.T, sess mongo.Session, args bson.Raw) (mongo
 
 	if sess != nil {
 		var res mongo.ListDatabasesResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var ldErr error
 			res, ldErr = mt.Client.ListDatabases(sc, filter)
 			return ldErr
@@ -579,7 +579,7 @@ func executeFindOne(mt *mtest.T, sess mongo.Session, args bson.Raw) *mongo.Singl
 
 	if sess != nil {
 		var res *mongo.SingleResult
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			res = mt.Coll.FindOne(sc, filter)
 			return nil
 		})
@@ -595,7 +595,7 @@ func executeListIndexes(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.
 	assert.Equal(mt, 0, len(args), "unexpected listIndexes arguments: %v", args)
 	if sess != nil {
 		var cursor *mongo.Cursor
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var listErr error
 			cursor, listErr = mt.Coll.Indexes().List(sc)
 			return listErr
@@ -632,7 +632,7 @@ func executeDistinct(mt *mtest.T, sess mongo.Session, args bson.Raw) ([]interfac
 
 	if sess != nil {
 		var res []interface{}
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var derr error
 			res, derr = mt.Coll.Distinct(sc, fieldName, filter, opts)
 			return derr
@@ -672,7 +672,7 @@ func executeFindOneAndDelete(mt *mtest.T, sess mongo.Session, args bson.Raw) *mo
 
 	if sess != nil {
 		var res *mongo.SingleResult
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			res = mt.Coll.FindOneAndDelete(sc, filter, opts)
 			return nil
 		})
@@ -729,7 +729,7 @@ func executeFindOneAndUpdate(mt *mtest.T, sess mongo.Session, args bson.Raw) *mo
 
 	if sess != nil {
 		var res *mongo.SingleResult
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			res = mt.Coll.FindOneAndUpdate(sc, filter, update, opts)
 			return nil
 		})
@@ -782,7 +782,7 @@ func executeFindOneAndReplace(mt *mtest.T, sess mongo.Session, args bson.Raw) *m
 
 	if sess != nil {
 		var res *mongo.SingleResult
-		_ = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		_ = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			res = mt.Coll.FindOneAndReplace(sc, filter, replacement, opts)
 			return nil
 		})
@@ -817,7 +817,7 @@ func executeDeleteOne(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.De
 
 	if sess != nil {
 		var res *mongo.DeleteResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var derr error
 			res, derr = mt.Coll.DeleteOne(sc, filter, opts)
 			return derr
@@ -853,7 +853,7 @@ func executeDeleteMany(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.D
 
 	if sess != nil {
 		var res *mongo.DeleteResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var derr error
 			res, derr = mt.Coll.DeleteMany(sc, filter, opts)
 			return derr
@@ -901,7 +901,7 @@ func executeUpdateOne(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.Up
 
 	if sess != nil {
 		var res *mongo.UpdateResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var uerr error
 			res, uerr = mt.Coll.UpdateOne(sc, filter, update, opts)
 			return uerr
@@ -949,7 +949,7 @@ func executeUpdateMany(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.U
 
 	if sess != nil {
 		var res *mongo.UpdateResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var uerr error
 			res, uerr = mt.Coll.UpdateMany(sc, filter, update, opts)
 			return uerr
@@ -993,7 +993,7 @@ func executeReplaceOne(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.U
 
 	if sess != nil {
 		var res *mongo.UpdateResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var uerr error
 			res, uerr = mt.Coll.ReplaceOne(sc, filter, replacement, opts)
 			return uerr
@@ -1077,7 +1077,7 @@ func executeBulkWrite(mt *mtest.T, sess mongo.Session, args bson.Raw) (*mongo.Bu
 
 	if sess != nil {
 		var res *mongo.BulkWriteResult
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var bwerr error
 			res, bwerr = mt.Coll.BulkWrite(sc, models, opts)
 			return bwerr
@@ -1206,7 +1206,7 @@ func executeEstimatedDocumentCount(mt *mtest.T, sess mongo.Session, args bson.Ra
 
 	if sess != nil {
 		var res int64
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var countErr error
 			res, countErr = mt.Coll.EstimatedDocumentCount(sc)
 			return countErr
@@ -1280,7 +1280,7 @@ func executeCreateIndex(mt *mtest.T, sess mongo.Session, args bson.Raw) (string,
 
 	if sess != nil {
 		var indexName string
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var indexErr error
 			indexName, indexErr = mt.Coll.Indexes().CreateOne(sc, model)
 			return indexErr
@@ -1309,7 +1309,7 @@ func executeDropIndex(mt *mtest.T, sess mongo.Session, args bson.Raw) (bson.Raw,
 
 	if sess != nil {
 		var res bson.Raw
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			var indexErr error
 			res, indexErr = mt.Coll.Indexes().DropOne(sc, name)
 			return indexErr
@@ -1340,7 +1340,7 @@ func executeDropCollection(mt *mtest.T, sess mongo.Session, args bson.Raw) error
 
 	coll := mt.DB.Collection(collName)
 	if sess != nil {
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			return coll.Drop(sc)
 		})
 		return err
@@ -1373,7 +1373,7 @@ func executeCreateCollection(mt *mtest.T, sess mongo.Session, args bson.Raw) err
 	}
 
 	if sess != nil {
-		err := mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err := mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			return mt.DB.CreateCollection(sc, collName, cco)
 		})
 		return err
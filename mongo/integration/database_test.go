@@ -309,6 +309,9 @@ func TestDatabase(t *testing.T) {
 				uuidSubtype, uuidData := cursor.Current.Lookup("info", "uuid").Binary()
 				expectedSpec.UUID = &primitive.Binary{Subtype: uuidSubtype, Data: uuidData}
 			}
+			if info, ok := cursor.Current.Lookup("info").DocumentOK(); ok {
+				expectedSpec.Info = info
+			}
 			if mtest.CompareServerVersions(mtest.ServerVersion(), "3.4") >= 0 {
 				keysDoc := bsoncore.NewDocumentBuilder().
 					AppendInt32("_id", 1).
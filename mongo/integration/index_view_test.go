@@ -209,6 +209,35 @@ func TestIndexView(t *testing.T) {
 				Value: true,
 			})
 		})
+		mt.RunOpts("hidden unsupported", mtest.NewOptions().MaxServerVersion("4.2"), func(mt *mtest.T) {
+			model := mongo.IndexModel{
+				Keys:    bson.D{{"x", int32(1)}},
+				Options: options.Index().SetHidden(true),
+			}
+			_, err := mt.Coll.Indexes().CreateOne(context.Background(), model)
+			assert.Equal(mt, mongo.ErrHiddenIndexNotSupported, err,
+				"expected error %v, got %v", mongo.ErrHiddenIndexNotSupported, err)
+		})
+		mt.RunOpts("hide and unhide", mtest.NewOptions().MinServerVersion("4.4"), func(mt *mtest.T) {
+			iv := mt.Coll.Indexes()
+			keysDoc := bson.D{{"x", int32(1)}}
+			name, err := iv.CreateOne(context.Background(), mongo.IndexModel{Keys: keysDoc})
+			assert.Nil(mt, err, "CreateOne error: %v", err)
+
+			err = iv.HideIndex(context.Background(), name)
+			assert.Nil(mt, err, "HideIndex error: %v", err)
+			indexDoc := getIndexDoc(mt, iv, keysDoc)
+			checkIndexDocContains(mt, indexDoc, bson.E{Key: "hidden", Value: true})
+
+			err = iv.UnhideIndex(context.Background(), name)
+			assert.Nil(mt, err, "UnhideIndex error: %v", err)
+			indexDoc = getIndexDoc(mt, iv, keysDoc)
+			for _, elem := range indexDoc {
+				if elem.Key == "hidden" {
+					assert.Equal(mt, false, elem.Value, "expected index to not be hidden, got %v", indexDoc)
+				}
+			}
+		})
 		mt.Run("nil keys", func(mt *mtest.T) {
 			_, err := mt.Coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
 				Keys: nil,
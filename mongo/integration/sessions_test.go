@@ -61,10 +61,10 @@ func TestSessionPool(t *testing.T) {
 		defer sess.EndSession(context.Background())
 		initialLastUsedTime := getSessionLastUsedTime(mt, sess)
 
-		err = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			return mt.Client.Ping(sc, readpref.Primary())
 		})
-		assert.Nil(mt, err, "WithSession error: %v", err)
+		assert.Nil(mt, err, "WithSessionContext error: %v", err)
 
 		newLastUsedTime := getSessionLastUsedTime(mt, sess)
 		assert.True(mt, newLastUsedTime.After(initialLastUsedTime),
@@ -353,7 +353,7 @@ func TestSessions(t *testing.T) {
 		assert.Nil(mt, err, "StartSession error: %v", err)
 		defer sess.EndSession(context.Background())
 
-		err = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		err = mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			_, err := mt.Coll.InsertOne(sc, bson.D{{"x", 1}})
 			return err
 		})
@@ -547,7 +547,7 @@ func (sf sessionFunction) execute(mt *mtest.T, sess mongo.Session) error {
 	paramsValues := interfaceSliceToValueSlice(sf.params)
 
 	if sess != nil {
-		return mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+		return mongo.WithSessionContext(context.Background(), sess, func(sc mongo.SessionContext) error {
 			valueArgs := []reflect.Value{reflect.ValueOf(sc)}
 			valueArgs = append(valueArgs, paramsValues...)
 			returnValues := fn.Call(valueArgs)
@@ -70,6 +70,39 @@ func (me MarshalError) Error() string {
 //	}
 type Pipeline []bson.D
 
+var _ bson.ValueUnmarshaler = (*Pipeline)(nil)
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface. It decodes a BSON array of documents, such as a
+// pipeline definition stored in a collection, into p, decoding each array element into a bson.D. Because a BSON array
+// has the same layout as a document, the bytes of an array can also be passed to bson.Unmarshal directly.
+func (p *Pipeline) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	switch t {
+	// bson.Unmarshal reports the top-level value as bsontype.Type(0).
+	case bsontype.Array, bsontype.EmbeddedDocument, bsontype.Type(0):
+	default:
+		return fmt.Errorf("cannot unmarshal BSON type %s into a Pipeline", t)
+	}
+
+	values, err := bsoncore.Array(b).Values()
+	if err != nil {
+		return err
+	}
+	pipeline := make(Pipeline, 0, len(values))
+	for i, val := range values {
+		doc, ok := val.DocumentOK()
+		if !ok {
+			return fmt.Errorf("pipeline stage %d must be a document, but got BSON type %s", i, val.Type)
+		}
+		var stage bson.D
+		if err := bson.Unmarshal(doc, &stage); err != nil {
+			return fmt.Errorf("error decoding pipeline stage %d: %w", i, err)
+		}
+		pipeline = append(pipeline, stage)
+	}
+	*p = pipeline
+	return nil
+}
+
 // transformAndEnsureID is a hack that makes it easy to get a RawValue as the _id value.
 // It will also add an ObjectID _id as the first key if it not already present in the passed-in val.
 func transformAndEnsureID(registry *bsoncodec.Registry, val interface{}) (bsoncore.Document, interface{}, error) {
@@ -153,16 +186,53 @@ func ensureDollarKey(doc bsoncore.Document) error {
 	}
 
 	if !strings.HasPrefix(firstElem.Key(), "$") {
-		return errors.New("update document must contain key beginning with '$'")
+		return fmt.Errorf("%w: found %q", ErrNoUpdateOperators, firstElem.Key())
 	}
 	return nil
 }
 
 func ensureNoDollarKey(doc bsoncore.Document) error {
-	if elem, err := doc.IndexErr(0); err == nil && strings.HasPrefix(elem.Key(), "$") {
-		return errors.New("replacement document cannot contain keys beginning with '$'")
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
 	}
 
+	var dollarKeys []string
+	for _, elem := range elems {
+		if strings.HasPrefix(elem.Key(), "$") {
+			dollarKeys = append(dollarKeys, elem.Key())
+		}
+	}
+	if len(dollarKeys) > 0 {
+		return fmt.Errorf("%w: found %v", ErrDocumentContainsUpdateOperators, dollarKeys)
+	}
+	return nil
+}
+
+// updatePipelineStages is the set of stages that are allowed in an update pipeline.
+var updatePipelineStages = map[string]struct{}{
+	"$addFields":   {},
+	"$set":         {},
+	"$project":     {},
+	"$unset":       {},
+	"$replaceRoot": {},
+	"$replaceWith": {},
+}
+
+// validateUpdatePipeline returns an error wrapping ErrInvalidUpdatePipelineStage if any stage in pipeline does not
+// consist of exactly one of the stages allowed in an update pipeline.
+func validateUpdatePipeline(pipeline Pipeline) error {
+	if len(pipeline) == 0 {
+		return errors.New("update pipeline must contain at least one stage")
+	}
+	for i, stage := range pipeline {
+		if len(stage) != 1 {
+			return fmt.Errorf("%w: stage %d must contain exactly one key, got %d", ErrInvalidUpdatePipelineStage, i, len(stage))
+		}
+		if _, ok := updatePipelineStages[stage[0].Key]; !ok {
+			return fmt.Errorf("%w: stage %d: %q", ErrInvalidUpdatePipelineStage, i, stage[0].Key)
+		}
+	}
 	return nil
 }
 
@@ -250,10 +320,12 @@ func transformAggregatePipeline(registry *bsoncodec.Registry, pipeline interface
 	}
 }
 
-func transformUpdateValue(registry *bsoncodec.Registry, update interface{}, dollarKeysAllowed bool) (bsoncore.Value, error) {
-	documentCheckerFunc := ensureDollarKey
-	if !dollarKeysAllowed {
-		documentCheckerFunc = ensureNoDollarKey
+// transformUpdateValue transforms an update document, replacement document, or update pipeline into a
+// bsoncore.Value. Each document is passed to documentCheckerFunc, which may be nil to skip checking.
+func transformUpdateValue(registry *bsoncodec.Registry, update interface{},
+	documentCheckerFunc func(bsoncore.Document) error) (bsoncore.Value, error) {
+	if documentCheckerFunc == nil {
+		documentCheckerFunc = func(bsoncore.Document) error { return nil }
 	}
 
 	var u bsoncore.Value
@@ -9,6 +9,7 @@ package mongo
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -462,6 +463,92 @@ func TestMongoHelpers(t *testing.T) {
 			})
 		}
 	})
+	t.Run("ensure dollar key", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			update interface{}
+			err    error
+		}{
+			{"update operators", bson.D{{"$set", bson.D{{"x", 1}}}}, nil},
+			{"replacement document", bson.D{{"x", 1}}, ErrNoUpdateOperators},
+			{"replacement map", bson.M{"x": 1}, ErrNoUpdateOperators},
+			{"pipeline", Pipeline{{{"$set", bson.D{{"x", 1}}}}}, nil},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := transformUpdateValue(bson.DefaultRegistry, tc.update, ensureDollarKey)
+				if tc.err == nil {
+					assert.Nil(t, err, "transformUpdateValue error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, tc.err), "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("ensure no dollar key", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			doc     bson.D
+			errKeys string
+		}{
+			{"replacement", bson.D{{"x", 1}, {"y", bson.D{{"$z", 1}}}}, ""},
+			{"first key", bson.D{{"$set", bson.D{{"x", 1}}}, {"y", 1}}, "[$set]"},
+			{"later keys", bson.D{{"x", 1}, {"$set", 1}, {"$inc", 1}}, "[$set $inc]"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				b, err := bson.Marshal(tc.doc)
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				err = ensureNoDollarKey(b)
+				if tc.errKeys == "" {
+					assert.Nil(t, err, "ensureNoDollarKey error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, ErrDocumentContainsUpdateOperators),
+					"expected error %v, got %v", ErrDocumentContainsUpdateOperators, err)
+				assert.True(t, strings.Contains(err.Error(), tc.errKeys),
+					"expected error to contain %q, got %q", tc.errKeys, err.Error())
+			})
+		}
+	})
+	t.Run("unmarshal pipeline", func(t *testing.T) {
+		pipeline := Pipeline{
+			{{"$match", bson.D{{"x", int32(1)}}}},
+			{{"$project", bson.D{{"_id", int32(0)}, {"x", int32(1)}}}},
+		}
+		_, arr, err := bson.MarshalValue(pipeline)
+		assert.Nil(t, err, "MarshalValue error: %v", err)
+
+		var fromValue Pipeline
+		err = bson.RawValue{Type: bsontype.Array, Value: arr}.Unmarshal(&fromValue)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, pipeline, fromValue, "expected pipeline %v, got %v", pipeline, fromValue)
+
+		var fromRaw Pipeline
+		err = bson.Unmarshal(arr, &fromRaw)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, pipeline, fromRaw, "expected pipeline %v, got %v", pipeline, fromRaw)
+
+		type storedPipeline struct {
+			Pipeline Pipeline `bson:"pipeline"`
+		}
+		doc, err := bson.Marshal(storedPipeline{pipeline})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		var stored storedPipeline
+		err = bson.Unmarshal(doc, &stored)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, pipeline, stored.Pipeline, "expected pipeline %v, got %v", pipeline, stored.Pipeline)
+
+		_, arr, err = bson.MarshalValue(bson.A{bson.D{{"$match", bson.D{}}}, "$project"})
+		assert.Nil(t, err, "MarshalValue error: %v", err)
+		var invalid Pipeline
+		err = bson.RawValue{Type: bsontype.Array, Value: arr}.Unmarshal(&invalid)
+		assert.NotNil(t, err, "expected error for non-document stage, got nil")
+
+		err = bson.RawValue{Type: bsontype.String, Value: bsoncore.AppendString(nil, "x")}.Unmarshal(&invalid)
+		assert.NotNil(t, err, "expected error for string value, got nil")
+	})
 }
 
 var _ bsoncodec.ValueMarshaler = bvMarsh{}
@@ -18,7 +18,10 @@ type AggregateOptions struct {
 	// the server. The default value is false.
 	AllowDiskUse *bool
 
-	// The maximum number of documents to be included in each batch returned by the server.
+	// The maximum number of documents to be included in each batch returned by the server. This is sent as the batchSize
+	// field of the cursor sub-document of the aggregate command. The default is nil, which means the field is omitted
+	// and the server's default batch size is used. A value of 0 is sent explicitly and requests an empty first batch,
+	// unless the pipeline contains a $out or $merge stage, in which case it is omitted.
 	BatchSize *int32
 
 	// If true, writes executed as part of the operation will opt out of document-level validation on the server. This
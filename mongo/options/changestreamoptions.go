@@ -7,26 +7,88 @@
 package options
 
 import (
+	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
 )
 
 // ChangeStreamOptions represents options that can be used to configure a Watch operation.
 type ChangeStreamOptions struct {
-	// The maximum number of documents to be included in each batch returned by the server.
+	// If false and FullDocument is options.UpdateLookup, the change stream will report an error for update events that
+	// do not include a fullDocument field because the document was deleted before it could be looked up. In this case,
+	// the change stream's Err method will return mongo.ErrMissingFullDocument. The default is nil, which means that
+	// such events are returned without a fullDocument field.
+	AllowMissingDocuments *bool
+
+	// AutoRecreateOnInvalidate is called when the server closes the change stream's cursor after an invalidate event,
+	// e.g. because the watched collection was dropped or renamed. If the function returns a resume token, the change
+	// stream is reopened with that token as the StartAfter option, which accepts the resume token of an invalidate
+	// event. Otherwise, if it returns an operation time, the change stream is reopened with that time as the
+	// StartAtOperationTime option. If it returns nil for both, the change stream stays closed. The function can block,
+	// for example to wait until the collection has been recreated. The invalidate event is still returned by Next and
+	// TryNext before the function is called. The default is nil, which means the change stream stays closed after it
+	// is invalidated.
+	AutoRecreateOnInvalidate func() (bson.Raw, *primitive.Timestamp)
+
+	// The maximum number of documents to be included in each batch returned by the server. A value of 0 is sent with the
+	// aggregate command that opens the change stream, so the server returns an open cursor with an empty first batch
+	// and all events are fetched by getMore commands. Because getMore does not accept a batch size of 0, the batch size
+	// is omitted from getMore commands in that case and the server's default is used. The default is nil, which means
+	// the server's default batch size is used for every command.
 	BatchSize *int32
 
+	// CheckpointOnEmptyBatch is called when the server returns an empty batch whose post-batch resume token advances
+	// the change stream's resume token. The token is passed to the function and can be stored to record the progress of
+	// the change stream even when no events are being returned, so a change stream on a collection with little traffic
+	// can be resumed from a recent position. This option is only useful for MongoDB versions >= 4.0.7, which return
+	// post-batch resume tokens. The default is nil, which means no function will be called.
+	CheckpointOnEmptyBatch func(bson.Raw)
+
 	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
 	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
 	// default value is nil, which means the default collation of the collection will be used.
 	Collation *Collation
 
+	// The command monitor to use for the commands sent by the change stream, including the initial aggregate, any
+	// aggregates sent to resume the change stream, and all getMore and killCursors commands. The default is nil, which
+	// means the command monitor configured on the Client will be used.
+	CommandMonitor *event.CommandMonitor
+
 	// A string that will be included in server logs, profiling logs, and currentOp queries to help trace the operation.
 	// The default is nil, which means that no comment will be included in the logs.
 	Comment *string
 
+	// Deployment specifies a custom deployment to use for server selection and topology information instead of the
+	// deployment of the Client used to open the change stream. This allows tests to simulate server selection and
+	// connection failures without a live cluster.
+	//
+	// Deprecated: This option is for testing only and should not be set by applications. It may be changed or removed
+	// in any release.
+	Deployment driver.Deployment
+
+	// If true, the change stream will verify that the clusterTime of each event is greater than or equal to the
+	// clusterTime of the previous event. If an event's clusterTime is earlier, the change stream's Err method will
+	// return mongo.ErrClusterTimeRegression. This should never happen and indicates that events may have been lost.
+	// The default is nil, which means that clusterTime values are not checked.
+	DetectClusterTimeRegression *bool
+
+	// If true and the change stream is opened on a Client, a $match stage is added after the $changeStream stage to
+	// exclude events from system databases. The databases excluded are specified by the SystemDatabases option. This
+	// option is ignored for change streams opened on a Collection or Database. The default is nil, which means events
+	// from all databases are returned.
+	ExcludeSystemNamespaces *bool
+
+	// If specified along with ResumeAfter or StartAfter, the change stream will be reopened at this operation time if
+	// the server rejects the resume token because it is invalid or no longer in the oplog. The fallback is attempted
+	// at most once when the change stream is created. This option is only valid for MongoDB versions >= 4.0. On
+	// older servers, the fallback returns an error.
+	FallbackStartAtOperationTime *primitive.Timestamp
+
 	// Specifies how the updated document should be returned in change notifications for update operations. The default
 	// is options.Default, which means that only partial update deltas will be included in the change notification.
 	FullDocument *FullDocument
@@ -35,19 +97,124 @@ type ChangeStreamOptions struct {
 	// is options.Off, which means that the pre-update document will not be included in the change notification.
 	FullDocumentBeforeChange *FullDocument
 
+	// If specified, the change stream will call this function to look up the current version of the document for update
+	// events that do not include a fullDocument field, and add the result to the event as its fullDocument field before
+	// returning it. The function is passed the event's documentKey and should return the document, or nil if the
+	// document no longer exists, in which case fullDocument is set to null. mongo.FindByDocumentKey can be used to
+	// create a function that reads from a Collection. This is intended for servers that do not support
+	// options.UpdateLookup. Each lookup requires an additional round trip, and the returned document reflects the state
+	// of the collection at the time of the lookup rather than the time of the update. The default is nil, which means no
+	// lookups will be performed.
+	FullDocumentViaFind func(ctx context.Context, documentKey bson.Raw) (bson.Raw, error)
+
+	// GetMoreLatencyHook is called after each attempt by the change stream to fetch a batch of events from its cursor,
+	// with the time the attempt took and the number of documents in the returned batch. The number of documents is 0 if
+	// the batch was empty or the attempt failed. Unlike the time taken by Next or TryNext, this only includes the time
+	// spent waiting for the server and does not include events returned from a batch that was already fetched. The
+	// first batch of each cursor is returned by the aggregate command, so its attempt takes almost no time. The
+	// default is nil, which means no function will be called.
+	GetMoreLatencyHook func(d time.Duration, docs int)
+
+	// The number of times to retry a getMore on the existing cursor if it fails with a network error, before the
+	// change stream falls back to resuming from the cached resume token. Retries wait with an exponential backoff,
+	// starting at 50 milliseconds. If the server ran a getMore whose reply was lost, the events in that reply are not
+	// returned by the retry. If every retry fails, the change stream resumes as it does when this option is not set.
+	// The default is nil, which means a failed getMore is never retried and the change stream resumes immediately.
+	GetMoreRetries *int
+
+	// If true, events that cannot be decoded by the change stream's NextDecode method are skipped rather than stopping
+	// the change stream. The decode error for each skipped event is sent to the channel returned by the change stream's
+	// DecodeErrors method. Skipped events are not returned again, even if the change stream resumes. The default is
+	// nil, which means a decode error stops the change stream.
+	LenientDecode *bool
+
+	// The maximum amount of time that the change stream may spend returning events that occurred before it was opened.
+	// The window starts when the first event is returned. If the change stream is still returning events with a
+	// clusterTime before the time it was opened once the window has elapsed, Next and TryNext will return false and
+	// the change stream's Err method will return mongo.ErrBackfillTooSlow. The default is nil, which means there is no
+	// limit.
+	MaxBackfillDuration *time.Duration
+
 	// The maximum amount of time that the server should wait for new documents to satisfy a tailable cursor query.
 	MaxAwaitTime *time.Duration
 
+	// The maximum number of documents to be included in each batch returned by a getMore command. Unlike BatchSize,
+	// this does not affect the aggregate command that opens the change stream. If both are set, MaxDocsPerGetMore is
+	// used for getMore commands and BatchSize is used for the aggregate. The default is nil, which means BatchSize is
+	// used for getMore commands.
+	MaxDocsPerGetMore *int32
+
+	// OnCursorClosed is called when the change stream detects that the server has closed its cursor, which happens
+	// when the server returns a cursor ID of 0 (e.g. after an invalidate event). It is called at most once for each
+	// cursor opened by the change stream. The default is nil, which means no function will be called.
+	OnCursorClosed func()
+
+	// If specified, a $match stage is added after the $changeStream stage so that the server only returns events whose
+	// operationType is one of the given types. The stage is placed before any stages in the pipeline passed to Watch.
+	// If OperationTypeInvalidate is not included, invalidate events are filtered out as well, so the change stream
+	// only learns that it was invalidated when its cursor is closed. The default is nil, which means events of all
+	// operation types are returned. An empty slice is treated the same as nil.
+	OperationTypes []OperationType
+
+	// RawEventCapture is called with a copy of the bytes of each event document in a batch returned by the server, in
+	// the order they were received and before the change stream processes them. Events that are later skipped or
+	// reassembled by the change stream, such as split event fragments, are also passed to the function. This can be
+	// used to record the exact events sent by the server when debugging. The default is nil, which means no function
+	// will be called.
+	RawEventCapture func([]byte)
+
+	// The read concern to use for the aggregate command that opens the change stream, including any aggregates sent to
+	// resume it. Change streams do not support the "linearizable" and "snapshot" read concern levels. The default is nil,
+	// which means the read concern of the Collection, Database, or Client used to open the change stream will be used.
+	ReadConcern *readconcern.ReadConcern
+
 	// A document specifying the logical starting point for the change stream. Only changes corresponding to an oplog
 	// entry immediately after the resume token will be returned. If this is specified, StartAtOperationTime and
 	// StartAfter must not be set.
 	ResumeAfter interface{}
 
+	// If specified, this function is called each time the change stream resumes with the stages from the pipeline
+	// passed to Watch, and the stages it returns are sent in their place. The stages added by the driver, such as the
+	// $changeStream stage, are not passed to the function and cannot be changed by it. A mongo.Pipeline can be converted
+	// to and from the []bson.D type used here. The returned stages are validated in the same way as the pipeline passed
+	// to Watch and the change stream reports an error if they are invalid. The default is nil, which means the pipeline
+	// is resent unchanged.
+	ResumePipelineFunc func(prev []bson.D) []bson.D
+
+	// The fraction of events, between 0 and 1, that Next and TryNext should return. Each event is independently kept
+	// with this probability and the remaining events are skipped, although the resume token still advances past them.
+	// Sampling is done by the client, so it does not reduce the number of events sent by the server. The default is
+	// nil, which means every event is returned.
+	SampleRate *float64
+
+	// The seed used to choose which events are kept when SampleRate is set. Change streams opened with the same seed
+	// and the same events skip the same events, which makes sampling reproducible. The default is nil, which means a
+	// seed is chosen based on the current time.
+	SampleSeed *int64
+
 	// ShowExpandedEvents specifies whether the server will return an expanded list of change stream events. Additional
 	// events include: createIndexes, dropIndexes, modify, create, shardCollection, reshardCollection and
 	// refineCollectionShardKey. This option is only valid for MongoDB versions >= 6.0.
 	ShowExpandedEvents *bool
 
+	// ShowSystemEvents specifies whether the server will return events for system collections and internal operations,
+	// such as chunk migrations to new shards. This option is only valid for MongoDB versions >= 6.0.
+	ShowSystemEvents *bool
+
+	// If true and FullDocumentBeforeChange is options.Required, update, replace, and delete events whose pre-image is
+	// not available are skipped instead of stopping the change stream, and the resume token advances past them. To do
+	// this, the change stream requests options.WhenAvailable from the server and skips events that do not have a
+	// fullDocumentBeforeChange value. The default is nil, which means a missing pre-image stops the change stream and
+	// its Err method returns an error that matches mongo.ErrPreImageUnavailable.
+	SkipMissingPreImage *bool
+
+	// If true, a $changeStreamSplitLargeEvent stage is added to the end of the pipeline so that the server splits
+	// events that exceed the 16MB BSON size limit into fragments instead of returning an error. The change stream
+	// reassembles the fragments of each split event before returning it from Next or TryNext, so the caller always
+	// sees complete events. This option is only valid for MongoDB versions >= 7.0. The default is nil, which means
+	// events are not split.
+	SplitLargeChanges *bool
+
 	// If specified, the change stream will only return changes that occurred at or after the given timestamp. This
 	// option is only valid for MongoDB versions >= 4.0. If this is specified, ResumeAfter and StartAfter must not be
 	// set.
@@ -60,6 +227,40 @@ type ChangeStreamOptions struct {
 	// ResumeAfter and StartAtOperationTime must not be set. This option is only valid for MongoDB versions >= 4.1.1.
 	StartAfter interface{}
 
+	// If specified, the change stream will start at the given operation time, like StartAtOperationTime, and will never
+	// be resumed at an earlier time. This can be used with the operationTime of a write (e.g. from the session used to
+	// perform it) to guarantee that the change stream observes that write and every change after it, even across
+	// resumes. The operation time of the change stream's session is also advanced to at least this time. If the time is
+	// no longer in the oplog when the change stream is opened or resumed without a resume token, the server returns an
+	// error, so the time should be well within the oplog retention window. If StartAtOperationTime is also specified,
+	// the later of the two times is used. If this is specified, ResumeAfter and StartAfter must not be set. This option
+	// is only valid for MongoDB versions >= 4.0.
+	StartAfterOperation *primitive.Timestamp
+
+	// If specified, the change stream will start from a position captured by the Checkpoint method of another change
+	// stream. If the checkpoint has a resume token, it is used as the ResumeAfter option. Otherwise, its operation time
+	// is used as the StartAtOperationTime option. If the checkpoint has neither, the change stream starts at the current
+	// time. If this is specified, ResumeAfter, StartAfter, StartAtOperationTime, and StartAfterOperation must not be
+	// set.
+	StartFromCheckpoint *ChangeStreamCheckpoint
+
+	// If true, every stage in the pipeline passed to Watch must be one of the stages that the server allows in a change
+	// stream pipeline ($addFields, $match, $project, $redact, $replaceRoot, $replaceWith, $set, and $unset), and Watch
+	// returns an error for any other stage. The default is nil, which means only stages that are known to be
+	// incompatible with change streams, such as $out, $merge, and $lookup, are rejected so that stages supported by
+	// newer servers are not rejected by the driver.
+	StrictPipelineValidation *bool
+
+	// The names of the databases whose events are excluded when ExcludeSystemNamespaces is true. The default is nil,
+	// which means the "admin", "config", and "local" databases are excluded.
+	SystemDatabases []string
+
+	// TerminalErrorPredicate is called when the change stream encounters a server error while getting the next batch
+	// of events. The error passed to the predicate is always of type mongo.CommandError. If the predicate returns
+	// true, the change stream stops and reports the error, even if the error would otherwise be resumable. The
+	// default is nil, which means errors are resumed according to the change stream specification.
+	TerminalErrorPredicate func(error) bool
+
 	// Custom options to be added to the initial aggregate for the change stream. Key-value pairs of the BSON map should
 	// correlate with desired option names and values. Values must be Marshalable. Custom options may conflict with
 	// non-custom options, and custom options bypass client-side validation. Prefer using non-custom options where possible.
@@ -78,24 +279,90 @@ func ChangeStream() *ChangeStreamOptions {
 	return cso
 }
 
+// SetAllowMissingDocuments sets the value for the AllowMissingDocuments field.
+func (cso *ChangeStreamOptions) SetAllowMissingDocuments(b bool) *ChangeStreamOptions {
+	cso.AllowMissingDocuments = &b
+	return cso
+}
+
+// SetAutoRecreateOnInvalidate sets the value for the AutoRecreateOnInvalidate field.
+func (cso *ChangeStreamOptions) SetAutoRecreateOnInvalidate(f func() (bson.Raw, *primitive.Timestamp)) *ChangeStreamOptions {
+	cso.AutoRecreateOnInvalidate = f
+	return cso
+}
+
 // SetBatchSize sets the value for the BatchSize field.
 func (cso *ChangeStreamOptions) SetBatchSize(i int32) *ChangeStreamOptions {
 	cso.BatchSize = &i
 	return cso
 }
 
+// SetCheckpointOnEmptyBatch sets the value for the CheckpointOnEmptyBatch field.
+func (cso *ChangeStreamOptions) SetCheckpointOnEmptyBatch(fn func(bson.Raw)) *ChangeStreamOptions {
+	cso.CheckpointOnEmptyBatch = fn
+	return cso
+}
+
 // SetCollation sets the value for the Collation field.
 func (cso *ChangeStreamOptions) SetCollation(c Collation) *ChangeStreamOptions {
 	cso.Collation = &c
 	return cso
 }
 
+// SetCommandMonitor sets the value for the CommandMonitor field.
+func (cso *ChangeStreamOptions) SetCommandMonitor(m *event.CommandMonitor) *ChangeStreamOptions {
+	cso.CommandMonitor = m
+	return cso
+}
+
 // SetComment sets the value for the Comment field.
 func (cso *ChangeStreamOptions) SetComment(comment string) *ChangeStreamOptions {
 	cso.Comment = &comment
 	return cso
 }
 
+// SetCrossClusterFallback sets the value for the FallbackStartAtOperationTime field. It is intended for resuming a
+// change stream on a different cluster than the one that issued the resume token, such as after failing over to a
+// disaster recovery cluster. Resume tokens are only valid on the cluster that issued them, so when the new cluster
+// rejects the token set by ResumeAfter or StartAfter, the change stream is opened at opTime instead.
+//
+// The oplogs of the two clusters are not related, so opTime can only approximate the position of the resume token.
+// Changes on the new cluster between opTime and the point corresponding to the resume token are returned again, and
+// any changes that were made on the old cluster but not replicated to the new one are lost. To avoid missing events,
+// opTime should be earlier than the last event processed from the old cluster, and events should be processed
+// idempotently.
+func (cso *ChangeStreamOptions) SetCrossClusterFallback(opTime *primitive.Timestamp) *ChangeStreamOptions {
+	cso.FallbackStartAtOperationTime = opTime
+	return cso
+}
+
+// SetDeployment sets the value for the Deployment field.
+//
+// Deprecated: This option is for testing only and should not be set by applications. It may be changed or removed in
+// any release.
+func (cso *ChangeStreamOptions) SetDeployment(d driver.Deployment) *ChangeStreamOptions {
+	cso.Deployment = d
+	return cso
+}
+
+// SetDetectClusterTimeRegression sets the value for the DetectClusterTimeRegression field.
+func (cso *ChangeStreamOptions) SetDetectClusterTimeRegression(b bool) *ChangeStreamOptions {
+	cso.DetectClusterTimeRegression = &b
+	return cso
+}
+
+// SetExcludeSystemNamespaces sets the value for the ExcludeSystemNamespaces field.
+func (cso *ChangeStreamOptions) SetExcludeSystemNamespaces(b bool) *ChangeStreamOptions {
+	cso.ExcludeSystemNamespaces = &b
+	return cso
+}
+
+// SetFallbackStartAtOperationTime sets the value for the FallbackStartAtOperationTime field.
+func (cso *ChangeStreamOptions) SetFallbackStartAtOperationTime(t *primitive.Timestamp) *ChangeStreamOptions {
+	cso.FallbackStartAtOperationTime = t
+	return cso
+}
+
 // SetFullDocument sets the value for the FullDocument field.
 func (cso *ChangeStreamOptions) SetFullDocument(fd FullDocument) *ChangeStreamOptions {
 	cso.FullDocument = &fd
@@ -108,24 +375,122 @@ func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fdbc FullDocument) *
 	return cso
 }
 
+// SetFullDocumentViaFind sets the value for the FullDocumentViaFind field.
+func (cso *ChangeStreamOptions) SetFullDocumentViaFind(
+	fn func(context.Context, bson.Raw) (bson.Raw, error)) *ChangeStreamOptions {
+
+	cso.FullDocumentViaFind = fn
+	return cso
+}
+
+// SetGetMoreLatencyHook sets the value for the GetMoreLatencyHook field.
+func (cso *ChangeStreamOptions) SetGetMoreLatencyHook(fn func(d time.Duration, docs int)) *ChangeStreamOptions {
+	cso.GetMoreLatencyHook = fn
+	return cso
+}
+
+// SetGetMoreRetries sets the value for the GetMoreRetries field.
+func (cso *ChangeStreamOptions) SetGetMoreRetries(n int) *ChangeStreamOptions {
+	cso.GetMoreRetries = &n
+	return cso
+}
+
+// SetLenientDecode sets the value for the LenientDecode field.
+func (cso *ChangeStreamOptions) SetLenientDecode(b bool) *ChangeStreamOptions {
+	cso.LenientDecode = &b
+	return cso
+}
+
+// SetMaxBackfillDuration sets the value for the MaxBackfillDuration field.
+func (cso *ChangeStreamOptions) SetMaxBackfillDuration(d time.Duration) *ChangeStreamOptions {
+	cso.MaxBackfillDuration = &d
+	return cso
+}
+
 // SetMaxAwaitTime sets the value for the MaxAwaitTime field.
 func (cso *ChangeStreamOptions) SetMaxAwaitTime(d time.Duration) *ChangeStreamOptions {
 	cso.MaxAwaitTime = &d
 	return cso
 }
 
+// SetMaxDocsPerGetMore sets the value for the MaxDocsPerGetMore field.
+func (cso *ChangeStreamOptions) SetMaxDocsPerGetMore(n int32) *ChangeStreamOptions {
+	cso.MaxDocsPerGetMore = &n
+	return cso
+}
+
+// SetOnCursorClosed sets the value for the OnCursorClosed field.
+func (cso *ChangeStreamOptions) SetOnCursorClosed(fn func()) *ChangeStreamOptions {
+	cso.OnCursorClosed = fn
+	return cso
+}
+
+// SetOperationTypes sets the value for the OperationTypes field.
+func (cso *ChangeStreamOptions) SetOperationTypes(types ...OperationType) *ChangeStreamOptions {
+	cso.OperationTypes = types
+	return cso
+}
+
+// SetRawEventCapture sets the value for the RawEventCapture field.
+func (cso *ChangeStreamOptions) SetRawEventCapture(fn func([]byte)) *ChangeStreamOptions {
+	cso.RawEventCapture = fn
+	return cso
+}
+
+// SetReadConcern sets the value for the ReadConcern field.
+func (cso *ChangeStreamOptions) SetReadConcern(rc *readconcern.ReadConcern) *ChangeStreamOptions {
+	cso.ReadConcern = rc
+	return cso
+}
+
 // SetResumeAfter sets the value for the ResumeAfter field.
 func (cso *ChangeStreamOptions) SetResumeAfter(rt interface{}) *ChangeStreamOptions {
 	cso.ResumeAfter = rt
 	return cso
 }
 
+// SetResumePipelineFunc sets the value for the ResumePipelineFunc field.
+func (cso *ChangeStreamOptions) SetResumePipelineFunc(fn func(prev []bson.D) []bson.D) *ChangeStreamOptions {
+	cso.ResumePipelineFunc = fn
+	return cso
+}
+
+// SetSampleRate sets the value for the SampleRate field.
+func (cso *ChangeStreamOptions) SetSampleRate(rate float64) *ChangeStreamOptions {
+	cso.SampleRate = &rate
+	return cso
+}
+
+// SetSampleSeed sets the value for the SampleSeed field.
+func (cso *ChangeStreamOptions) SetSampleSeed(seed int64) *ChangeStreamOptions {
+	cso.SampleSeed = &seed
+	return cso
+}
+
 // SetShowExpandedEvents sets the value for the ShowExpandedEvents field.
 func (cso *ChangeStreamOptions) SetShowExpandedEvents(see bool) *ChangeStreamOptions {
 	cso.ShowExpandedEvents = &see
 	return cso
 }
 
+// SetShowSystemEvents sets the value for the ShowSystemEvents field.
+func (cso *ChangeStreamOptions) SetShowSystemEvents(sse bool) *ChangeStreamOptions {
+	cso.ShowSystemEvents = &sse
+	return cso
+}
+
+// SetSkipMissingPreImage sets the value for the SkipMissingPreImage field.
+func (cso *ChangeStreamOptions) SetSkipMissingPreImage(b bool) *ChangeStreamOptions {
+	cso.SkipMissingPreImage = &b
+	return cso
+}
+
+// SetSplitLargeChanges sets the value for the SplitLargeChanges field.
+func (cso *ChangeStreamOptions) SetSplitLargeChanges(b bool) *ChangeStreamOptions {
+	cso.SplitLargeChanges = &b
+	return cso
+}
+
 // SetStartAtOperationTime sets the value for the StartAtOperationTime field.
 func (cso *ChangeStreamOptions) SetStartAtOperationTime(t *primitive.Timestamp) *ChangeStreamOptions {
 	cso.StartAtOperationTime = t
@@ -138,6 +503,36 @@ func (cso *ChangeStreamOptions) SetStartAfter(sa interface{}) *ChangeStreamOptio
 	return cso
 }
 
+// SetStartAfterOperation sets the value for the StartAfterOperation field.
+func (cso *ChangeStreamOptions) SetStartAfterOperation(t *primitive.Timestamp) *ChangeStreamOptions {
+	cso.StartAfterOperation = t
+	return cso
+}
+
+// SetStartFromCheckpoint sets the value for the StartFromCheckpoint field.
+func (cso *ChangeStreamOptions) SetStartFromCheckpoint(cp ChangeStreamCheckpoint) *ChangeStreamOptions {
+	cso.StartFromCheckpoint = &cp
+	return cso
+}
+
+// SetStrictPipelineValidation sets the value for the StrictPipelineValidation field.
+func (cso *ChangeStreamOptions) SetStrictPipelineValidation(b bool) *ChangeStreamOptions {
+	cso.StrictPipelineValidation = &b
+	return cso
+}
+
+// SetSystemDatabases sets the value for the SystemDatabases field.
+func (cso *ChangeStreamOptions) SetSystemDatabases(dbs ...string) *ChangeStreamOptions {
+	cso.SystemDatabases = dbs
+	return cso
+}
+
+// SetTerminalErrorPredicate sets the value for the TerminalErrorPredicate field.
+func (cso *ChangeStreamOptions) SetTerminalErrorPredicate(fn func(error) bool) *ChangeStreamOptions {
+	cso.TerminalErrorPredicate = fn
+	return cso
+}
+
 // SetCustom sets the value for the Custom field. Key-value pairs of the BSON map should correlate
 // with desired option names and values. Values must be Marshalable. Custom options may conflict
 // with non-custom options, and custom options bypass client-side validation. Prefer using non-custom
@@ -163,36 +558,123 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso == nil {
 			continue
 		}
+		if cso.AllowMissingDocuments != nil {
+			csOpts.AllowMissingDocuments = cso.AllowMissingDocuments
+		}
+		if cso.AutoRecreateOnInvalidate != nil {
+			csOpts.AutoRecreateOnInvalidate = cso.AutoRecreateOnInvalidate
+		}
 		if cso.BatchSize != nil {
 			csOpts.BatchSize = cso.BatchSize
 		}
+		if cso.CheckpointOnEmptyBatch != nil {
+			csOpts.CheckpointOnEmptyBatch = cso.CheckpointOnEmptyBatch
+		}
 		if cso.Collation != nil {
 			csOpts.Collation = cso.Collation
 		}
+		if cso.CommandMonitor != nil {
+			csOpts.CommandMonitor = cso.CommandMonitor
+		}
 		if cso.Comment != nil {
 			csOpts.Comment = cso.Comment
 		}
+		if cso.Deployment != nil {
+			csOpts.Deployment = cso.Deployment
+		}
+		if cso.DetectClusterTimeRegression != nil {
+			csOpts.DetectClusterTimeRegression = cso.DetectClusterTimeRegression
+		}
+		if cso.ExcludeSystemNamespaces != nil {
+			csOpts.ExcludeSystemNamespaces = cso.ExcludeSystemNamespaces
+		}
+		if cso.FallbackStartAtOperationTime != nil {
+			csOpts.FallbackStartAtOperationTime = cso.FallbackStartAtOperationTime
+		}
 		if cso.FullDocument != nil {
 			csOpts.FullDocument = cso.FullDocument
 		}
 		if cso.FullDocumentBeforeChange != nil {
 			csOpts.FullDocumentBeforeChange = cso.FullDocumentBeforeChange
 		}
+		if cso.FullDocumentViaFind != nil {
+			csOpts.FullDocumentViaFind = cso.FullDocumentViaFind
+		}
+		if cso.GetMoreLatencyHook != nil {
+			csOpts.GetMoreLatencyHook = cso.GetMoreLatencyHook
+		}
+		if cso.GetMoreRetries != nil {
+			csOpts.GetMoreRetries = cso.GetMoreRetries
+		}
+		if cso.LenientDecode != nil {
+			csOpts.LenientDecode = cso.LenientDecode
+		}
+		if cso.MaxBackfillDuration != nil {
+			csOpts.MaxBackfillDuration = cso.MaxBackfillDuration
+		}
 		if cso.MaxAwaitTime != nil {
 			csOpts.MaxAwaitTime = cso.MaxAwaitTime
 		}
+		if cso.MaxDocsPerGetMore != nil {
+			csOpts.MaxDocsPerGetMore = cso.MaxDocsPerGetMore
+		}
+		if cso.OnCursorClosed != nil {
+			csOpts.OnCursorClosed = cso.OnCursorClosed
+		}
+		if len(cso.OperationTypes) > 0 {
+			csOpts.OperationTypes = cso.OperationTypes
+		}
+		if cso.RawEventCapture != nil {
+			csOpts.RawEventCapture = cso.RawEventCapture
+		}
+		if cso.ReadConcern != nil {
+			csOpts.ReadConcern = cso.ReadConcern
+		}
 		if cso.ResumeAfter != nil {
 			csOpts.ResumeAfter = cso.ResumeAfter
 		}
+		if cso.ResumePipelineFunc != nil {
+			csOpts.ResumePipelineFunc = cso.ResumePipelineFunc
+		}
+		if cso.SampleRate != nil {
+			csOpts.SampleRate = cso.SampleRate
+		}
+		if cso.SampleSeed != nil {
+			csOpts.SampleSeed = cso.SampleSeed
+		}
 		if cso.ShowExpandedEvents != nil {
 			csOpts.ShowExpandedEvents = cso.ShowExpandedEvents
 		}
+		if cso.ShowSystemEvents != nil {
+			csOpts.ShowSystemEvents = cso.ShowSystemEvents
+		}
+		if cso.SkipMissingPreImage != nil {
+			csOpts.SkipMissingPreImage = cso.SkipMissingPreImage
+		}
+		if cso.SplitLargeChanges != nil {
+			csOpts.SplitLargeChanges = cso.SplitLargeChanges
+		}
 		if cso.StartAtOperationTime != nil {
 			csOpts.StartAtOperationTime = cso.StartAtOperationTime
 		}
 		if cso.StartAfter != nil {
 			csOpts.StartAfter = cso.StartAfter
 		}
+		if cso.StartAfterOperation != nil {
+			csOpts.StartAfterOperation = cso.StartAfterOperation
+		}
+		if cso.StartFromCheckpoint != nil {
+			csOpts.StartFromCheckpoint = cso.StartFromCheckpoint
+		}
+		if cso.StrictPipelineValidation != nil {
+			csOpts.StrictPipelineValidation = cso.StrictPipelineValidation
+		}
+		if cso.SystemDatabases != nil {
+			csOpts.SystemDatabases = cso.SystemDatabases
+		}
+		if cso.TerminalErrorPredicate != nil {
+			csOpts.TerminalErrorPredicate = cso.TerminalErrorPredicate
+		}
 		if cso.Custom != nil {
 			csOpts.Custom = cso.Custom
 		}
@@ -203,3 +685,20 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 
 	return csOpts
 }
+
+// ChangeStreamCheckpoint is the position of a change stream captured by the change stream's Checkpoint method. It can
+// be passed to the StartFromCheckpoint option to open a change stream at the same position. A checkpoint can be
+// stored by marshalling it to BSON with bson.Marshal and restored with bson.Unmarshal. Its fields should not be
+// modified.
+type ChangeStreamCheckpoint struct {
+	// The resume token of the change stream, or nil if it had not cached a resume token.
+	ResumeToken bson.Raw `bson:"resumeToken,omitempty"`
+
+	// Whether ResumeToken is a post-batch resume token returned by the server with an empty batch rather than the
+	// resume token of an event.
+	PostBatchResumeToken bool `bson:"postBatchResumeToken"`
+
+	// The operation time that the change stream would resume from if it did not have a resume token, or nil if there
+	// is none.
+	OperationTime *primitive.Timestamp `bson:"operationTime,omitempty"`
+}
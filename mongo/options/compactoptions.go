@@ -0,0 +1,68 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// CompactOptions represents options that can be used to configure a Compact operation.
+type CompactOptions struct {
+	// A string that will be included in server logs, profiling logs, and currentOp queries to help trace the operation.
+	// The default is nil, which means that no comment will be included in the logs.
+	Comment *string
+
+	// If true, the command is allowed to run on the primary of a replica set. The default is nil, which means the
+	// server default of false will be used.
+	Force *bool
+
+	// The minimum amount of storage space, in megabytes, that must be recoverable for the command to compact the
+	// collection. This option is only valid for MongoDB versions >= 4.4. The default is nil, which means the collection
+	// is always compacted.
+	FreeSpaceTargetMB *int64
+}
+
+// Compact creates a new CompactOptions instance.
+func Compact() *CompactOptions {
+	return &CompactOptions{}
+}
+
+// SetComment sets the value for the Comment field.
+func (co *CompactOptions) SetComment(comment string) *CompactOptions {
+	co.Comment = &comment
+	return co
+}
+
+// SetForce sets the value for the Force field.
+func (co *CompactOptions) SetForce(b bool) *CompactOptions {
+	co.Force = &b
+	return co
+}
+
+// SetFreeSpaceTargetMB sets the value for the FreeSpaceTargetMB field.
+func (co *CompactOptions) SetFreeSpaceTargetMB(mb int64) *CompactOptions {
+	co.FreeSpaceTargetMB = &mb
+	return co
+}
+
+// MergeCompactOptions combines the given CompactOptions instances into a single CompactOptions in a last-one-wins
+// fashion.
+func MergeCompactOptions(opts ...*CompactOptions) *CompactOptions {
+	c := Compact()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Comment != nil {
+			c.Comment = opt.Comment
+		}
+		if opt.Force != nil {
+			c.Force = opt.Force
+		}
+		if opt.FreeSpaceTargetMB != nil {
+			c.FreeSpaceTargetMB = opt.FreeSpaceTargetMB
+		}
+	}
+
+	return c
+}
@@ -647,6 +647,11 @@ type FindOneAndReplaceOptions struct {
 	// Before, which means the original document will be returned from before the replacement is performed.
 	ReturnDocument *ReturnDocument
 
+	// If true, the driver will not check the replacement document for top-level keys beginning with '$' before sending
+	// it to the server. By default, the driver returns an error wrapping mongo.ErrDocumentContainsUpdateOperators if
+	// any such keys are found.
+	SkipOperatorCheck *bool
+
 	// A document specifying which document should be replaced if the filter used by the operation matches multiple
 	// documents in the collection. If set, the first document in the sorted order will be replaced. The driver will
 	// return an error if the sort parameter is a multi-key map. The default value is nil.
@@ -716,6 +721,12 @@ func (f *FindOneAndReplaceOptions) SetReturnDocument(rd ReturnDocument) *FindOne
 	return f
 }
 
+// SetSkipOperatorCheck sets the value for the SkipOperatorCheck field.
+func (f *FindOneAndReplaceOptions) SetSkipOperatorCheck(b bool) *FindOneAndReplaceOptions {
+	f.SkipOperatorCheck = &b
+	return f
+}
+
 // SetSort sets the value for the Sort field.
 func (f *FindOneAndReplaceOptions) SetSort(sort interface{}) *FindOneAndReplaceOptions {
 	f.Sort = sort
@@ -766,6 +777,9 @@ func MergeFindOneAndReplaceOptions(opts ...*FindOneAndReplaceOptions) *FindOneAn
 		if opt.ReturnDocument != nil {
 			fo.ReturnDocument = opt.ReturnDocument
 		}
+		if opt.SkipOperatorCheck != nil {
+			fo.SkipOperatorCheck = opt.SkipOperatorCheck
+		}
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
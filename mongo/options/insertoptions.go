@@ -117,3 +117,65 @@ func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
 
 	return imOpts
 }
+
+// InsertOrFindOptions represents options that can be used to configure an InsertOrFind operation.
+type InsertOrFindOptions struct {
+	// If true, writes executed as part of the operation will opt out of document-level validation on the server. This
+	// option is valid for MongoDB versions >= 3.2 and is ignored for previous server versions. The default value is
+	// false. See https://www.mongodb.com/docs/manual/core/schema-validation/ for more information about document
+	// validation.
+	BypassDocumentValidation *bool
+
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation.  The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// A document describing which fields will be included in the returned document. The default value is nil, which
+	// means all fields will be included.
+	Projection interface{}
+}
+
+// InsertOrFind creates a new InsertOrFindOptions instance.
+func InsertOrFind() *InsertOrFindOptions {
+	return &InsertOrFindOptions{}
+}
+
+// SetBypassDocumentValidation sets the value for the BypassDocumentValidation field.
+func (iofo *InsertOrFindOptions) SetBypassDocumentValidation(b bool) *InsertOrFindOptions {
+	iofo.BypassDocumentValidation = &b
+	return iofo
+}
+
+// SetComment sets the value for the Comment field.
+func (iofo *InsertOrFindOptions) SetComment(comment interface{}) *InsertOrFindOptions {
+	iofo.Comment = comment
+	return iofo
+}
+
+// SetProjection sets the value for the Projection field.
+func (iofo *InsertOrFindOptions) SetProjection(projection interface{}) *InsertOrFindOptions {
+	iofo.Projection = projection
+	return iofo
+}
+
+// MergeInsertOrFindOptions combines the given InsertOrFindOptions instances into a single InsertOrFindOptions in a
+// last-one-wins fashion.
+func MergeInsertOrFindOptions(opts ...*InsertOrFindOptions) *InsertOrFindOptions {
+	iofOpts := InsertOrFind()
+	for _, iofo := range opts {
+		if iofo == nil {
+			continue
+		}
+		if iofo.BypassDocumentValidation != nil {
+			iofOpts.BypassDocumentValidation = iofo.BypassDocumentValidation
+		}
+		if iofo.Comment != nil {
+			iofOpts.Comment = iofo.Comment
+		}
+		if iofo.Projection != nil {
+			iofOpts.Projection = iofo.Projection
+		}
+	}
+
+	return iofOpts
+}
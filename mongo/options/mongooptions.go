@@ -107,6 +107,21 @@ const (
 	WhenAvailable FullDocument = "whenAvailable"
 )
 
+// OperationType specifies the type of operation that a change stream event describes.
+type OperationType string
+
+// These constants are the operation types of the change events that can be returned by a change stream.
+const (
+	OperationTypeInsert       OperationType = "insert"
+	OperationTypeUpdate       OperationType = "update"
+	OperationTypeReplace      OperationType = "replace"
+	OperationTypeDelete       OperationType = "delete"
+	OperationTypeDrop         OperationType = "drop"
+	OperationTypeRename       OperationType = "rename"
+	OperationTypeDropDatabase OperationType = "dropDatabase"
+	OperationTypeInvalidate   OperationType = "invalidate"
+)
+
 // ArrayFilters is used to hold filters for the array filters CRUD option. If a registry is nil, bson.DefaultRegistry
 // will be used when converting the filter interfaces to BSON.
 type ArrayFilters struct {
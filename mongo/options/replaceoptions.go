@@ -31,6 +31,11 @@ type ReplaceOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// If true, the driver will not check the replacement document for top-level keys beginning with '$' before sending
+	// it to the server. By default, the driver returns an error wrapping mongo.ErrDocumentContainsUpdateOperators if
+	// any such keys are found.
+	SkipOperatorCheck *bool
+
 	// If true, a new document will be inserted if the filter does not match any documents in the collection. The
 	// default value is false.
 	Upsert *bool
@@ -71,6 +76,12 @@ func (ro *ReplaceOptions) SetHint(h interface{}) *ReplaceOptions {
 	return ro
 }
 
+// SetSkipOperatorCheck sets the value for the SkipOperatorCheck field.
+func (ro *ReplaceOptions) SetSkipOperatorCheck(b bool) *ReplaceOptions {
+	ro.SkipOperatorCheck = &b
+	return ro
+}
+
 // SetUpsert sets the value for the Upsert field.
 func (ro *ReplaceOptions) SetUpsert(b bool) *ReplaceOptions {
 	ro.Upsert = &b
@@ -103,6 +114,9 @@ func MergeReplaceOptions(opts ...*ReplaceOptions) *ReplaceOptions {
 		if ro.Hint != nil {
 			rOpts.Hint = ro.Hint
 		}
+		if ro.SkipOperatorCheck != nil {
+			rOpts.SkipOperatorCheck = ro.SkipOperatorCheck
+		}
 		if ro.Upsert != nil {
 			rOpts.Upsert = ro.Upsert
 		}
@@ -6,6 +6,12 @@
 
 package options
 
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
 // UpdateOptions represents options that can be used to configure UpdateOne and UpdateMany operations.
 type UpdateOptions struct {
 	// A set of filters specifying to which array elements an update should apply. This option is only valid for MongoDB
@@ -36,6 +42,12 @@ type UpdateOptions struct {
 	// which means that no hint will be sent.
 	Hint interface{}
 
+	// If true, Collection.UpdateByID will replace the matched document instead of updating it when the update
+	// parameter is a document whose keys do not begin with '$'. Update documents containing update operators and
+	// update pipelines are still applied as updates. This option is ignored by all other operations. The default
+	// value is false.
+	ReplaceDoc *bool
+
 	// If true, a new document will be inserted if the filter does not match any documents in the collection. The
 	// default value is false.
 	Upsert *bool
@@ -45,6 +57,8 @@ type UpdateOptions struct {
 	// Values must be constant or closed expressions that do not reference document fields. Parameters can then be
 	// accessed as variables in an aggregate expression context (e.g. "$$var").
 	Let interface{}
+
+	err error
 }
 
 // Update creates a new UpdateOptions instance.
@@ -76,9 +90,26 @@ func (uo *UpdateOptions) SetComment(comment interface{}) *UpdateOptions {
 	return uo
 }
 
-// SetHint sets the value for the Hint field.
+// SetHint sets the value for the Hint field. It replaces a hint set by SetHintDoc, including any validation error.
 func (uo *UpdateOptions) SetHint(h interface{}) *UpdateOptions {
 	uo.Hint = h
+	uo.err = nil
+	return uo
+}
+
+// SetHintDoc sets the value for the Hint field to an index specification document. Each value in keys must be 1
+// (ascending) or -1 (descending). If keys is empty or contains any other value, the operation will return an error
+// without being sent to the server. Hints set on an UpdateOneModel or UpdateManyModel for BulkWrite are not validated
+// this way.
+func (uo *UpdateOptions) SetHintDoc(keys bson.D) *UpdateOptions {
+	uo.Hint = keys
+	uo.err = validateHintDoc(keys)
+	return uo
+}
+
+// SetReplaceDoc sets the value for the ReplaceDoc field.
+func (uo *UpdateOptions) SetReplaceDoc(b bool) *UpdateOptions {
+	uo.ReplaceDoc = &b
 	return uo
 }
 
@@ -94,6 +125,11 @@ func (uo *UpdateOptions) SetLet(l interface{}) *UpdateOptions {
 	return uo
 }
 
+// Validate validates the update options. This method will return the first error found.
+func (uo *UpdateOptions) Validate() error {
+	return uo.err
+}
+
 // MergeUpdateOptions combines the given UpdateOptions instances into a single UpdateOptions in a last-one-wins fashion.
 func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 	uOpts := Update()
@@ -115,6 +151,10 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 		}
 		if uo.Hint != nil {
 			uOpts.Hint = uo.Hint
+			uOpts.err = uo.err
+		}
+		if uo.ReplaceDoc != nil {
+			uOpts.ReplaceDoc = uo.ReplaceDoc
 		}
 		if uo.Upsert != nil {
 			uOpts.Upsert = uo.Upsert
@@ -126,3 +166,28 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 
 	return uOpts
 }
+
+func validateHintDoc(keys bson.D) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("hint document must contain at least one key")
+	}
+	for _, elem := range keys {
+		var dir float64
+		switch v := elem.Value.(type) {
+		case int:
+			dir = float64(v)
+		case int32:
+			dir = float64(v)
+		case int64:
+			dir = float64(v)
+		case float64:
+			dir = v
+		default:
+			return fmt.Errorf("hint key %q must have value 1 or -1, got %v of type %T", elem.Key, elem.Value, elem.Value)
+		}
+		if dir != 1 && dir != -1 {
+			return fmt.Errorf("hint key %q must have value 1 or -1, got %v", elem.Key, elem.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (C) MongoDB, Inc. 2022-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestUpdateOptions(t *testing.T) {
+	t.Run("SetHintDoc", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			keys    bson.D
+			wantErr bool
+		}{
+			{"ascending and descending", bson.D{{"a", 1}, {"b", int32(-1)}, {"c", int64(1)}, {"d", -1.0}}, false},
+			{"empty", bson.D{}, true},
+			{"string value", bson.D{{"a", "hashed"}}, true},
+			{"out of range", bson.D{{"a", 2}}, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				uo := Update().SetHintDoc(tc.keys)
+				assert.Equal(t, tc.keys, uo.Hint, "expected Hint %v, got %v", tc.keys, uo.Hint)
+
+				err := uo.Validate()
+				if tc.wantErr {
+					assert.NotNil(t, err, "expected error, got nil")
+				} else {
+					assert.Nil(t, err, "Validate error: %v", err)
+				}
+			})
+		}
+	})
+	t.Run("SetHint clears hint doc error", func(t *testing.T) {
+		uo := Update().SetHintDoc(bson.D{{"a", "1"}}).SetHint("a_1")
+		assert.Equal(t, "a_1", uo.Hint, "expected Hint %v, got %v", "a_1", uo.Hint)
+		err := uo.Validate()
+		assert.Nil(t, err, "Validate error: %v", err)
+
+		err = MergeUpdateOptions(Update().SetHintDoc(bson.D{{"a", "1"}}), uo).Validate()
+		assert.Nil(t, err, "Validate error: %v", err)
+	})
+	t.Run("merge keeps last hint", func(t *testing.T) {
+		invalid := Update().SetHintDoc(bson.D{{"a", "1"}})
+		valid := Update().SetHint("a_1")
+
+		err := MergeUpdateOptions(valid, invalid).Validate()
+		assert.NotNil(t, err, "expected error, got nil")
+		err = MergeUpdateOptions(invalid, valid).Validate()
+		assert.Nil(t, err, "Validate error: %v", err)
+	})
+}
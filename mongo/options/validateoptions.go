@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ValidateOptions represents options that can be used to configure a Validate operation.
+type ValidateOptions struct {
+	// If true, the command performs a more thorough but slower check of the collection's data and indexes. The default
+	// is nil, which means the server default of false will be used.
+	Full *bool
+
+	// If true, the command repairs inconsistencies that it finds. This option is only valid for MongoDB versions >= 5.0
+	// and can only be used on a standalone server. The default is nil, which means the server default of false will be
+	// used.
+	Repair *bool
+}
+
+// Validate creates a new ValidateOptions instance.
+func Validate() *ValidateOptions {
+	return &ValidateOptions{}
+}
+
+// SetFull sets the value for the Full field.
+func (vo *ValidateOptions) SetFull(b bool) *ValidateOptions {
+	vo.Full = &b
+	return vo
+}
+
+// SetRepair sets the value for the Repair field.
+func (vo *ValidateOptions) SetRepair(b bool) *ValidateOptions {
+	vo.Repair = &b
+	return vo
+}
+
+// MergeValidateOptions combines the given ValidateOptions instances into a single ValidateOptions in a last-one-wins
+// fashion.
+func MergeValidateOptions(opts ...*ValidateOptions) *ValidateOptions {
+	v := Validate()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Full != nil {
+			v.Full = opt.Full
+		}
+		if opt.Repair != nil {
+			v.Repair = opt.Repair
+		}
+	}
+
+	return v
+}
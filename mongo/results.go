@@ -35,6 +35,36 @@ type BulkWriteResult struct {
 	UpsertedIDs map[int64]interface{}
 }
 
+// WriteModelResult is the result of a single write model executed by a BulkWriteWithResult operation.
+type WriteModelResult struct {
+	// The number of documents inserted by an insert operation.
+	InsertedCount int64
+
+	// The number of documents matched by the filter of an update or replace operation.
+	MatchedCount int64
+
+	// The number of documents modified by an update or replace operation.
+	ModifiedCount int64
+
+	// The number of documents deleted by a delete operation.
+	DeletedCount int64
+
+	// The _id of the document upserted by an update or replace operation, or nil if no document was upserted.
+	UpsertedID interface{}
+
+	// The error that occurred while executing the operation, or nil if it succeeded. If not nil, this will be of type
+	// BulkWriteException.
+	Error error
+}
+
+// BulkWriteDetailedResult is the result type returned by a BulkWriteWithResult operation.
+type BulkWriteDetailedResult struct {
+	// The result of each write model that was executed, in the order that the models were passed to
+	// BulkWriteWithResult. For an ordered bulk write that stops at an error, models after the failed one are not
+	// included.
+	Results []WriteModelResult
+}
+
 // InsertOneResult is the result type returned by an InsertOne operation.
 type InsertOneResult struct {
 	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
@@ -54,6 +84,34 @@ type DeleteResult struct {
 	DeletedCount int64 `bson:"n"` // The number of documents deleted.
 }
 
+// CompactResult is the result type returned by a Compact operation.
+type CompactResult struct {
+	BytesFreed int64 `bson:"bytesFreed"` // The number of bytes freed by the operation.
+}
+
+// ValidationResult is the result type returned by a Validate operation.
+type ValidationResult struct {
+	Ns           string           `bson:"ns"`           // The namespace of the collection.
+	Nrecords     int64            `bson:"nrecords"`     // The number of documents in the collection.
+	Nindexes     int64            `bson:"nIndexes"`     // The number of indexes on the collection.
+	KeysPerIndex map[string]int64 `bson:"keysPerIndex"` // The number of keys in each index, keyed by index name.
+	Valid        bool             `bson:"valid"`        // Whether the collection and its indexes are valid.
+	Errors       []string         `bson:"errors"`       // The problems found with the collection or its indexes.
+}
+
+// Page is the result type returned by a PaginatedFind operation.
+type Page struct {
+	// The documents in the page, in _id order.
+	Docs []bson.Raw
+
+	// The _id of the last document in the page, which can be passed to PaginatedFind to get the next page. It is nil
+	// if the page is empty.
+	NextCursor *primitive.ObjectID
+
+	// Whether there are more documents after this page.
+	HasMore bool
+}
+
 // RewrapManyDataKeyResult is the result of the bulk write operation used to update the key vault collection with
 // rewrapped data keys.
 type RewrapManyDataKeyResult struct {
@@ -240,6 +298,11 @@ type CollectionSpecification struct {
 	// An IndexSpecification instance with details about the collection's _id index. This will be nil if the NameOnly
 	// option is used and for MongoDB versions < 3.4.
 	IDIndex *IndexSpecification
+
+	// The info document returned by the server for the collection. The readOnly and uuid fields of this document are
+	// also available via the ReadOnly and UUID fields. This will be nil if the NameOnly option is used and for MongoDB
+	// versions < 3.4.
+	Info bson.Raw
 }
 
 var _ bson.Unmarshaler = (*CollectionSpecification)(nil)
@@ -247,16 +310,19 @@ var _ bson.Unmarshaler = (*CollectionSpecification)(nil)
 // unmarshalCollectionSpecification is used to unmarshal BSON bytes from a listCollections command into a
 // CollectionSpecification.
 type unmarshalCollectionSpecification struct {
-	Name string `bson:"name"`
-	Type string `bson:"type"`
-	Info *struct {
-		ReadOnly bool              `bson:"readOnly"`
-		UUID     *primitive.Binary `bson:"uuid"`
-	} `bson:"info"`
+	Name    string              `bson:"name"`
+	Type    string              `bson:"type"`
+	Info    bson.Raw            `bson:"info"`
 	Options bson.Raw            `bson:"options"`
 	IDIndex *IndexSpecification `bson:"idIndex"`
 }
 
+// unmarshalCollectionInfo is used to unmarshal the info document of a listCollections result.
+type unmarshalCollectionInfo struct {
+	ReadOnly bool              `bson:"readOnly"`
+	UUID     *primitive.Binary `bson:"uuid"`
+}
+
 // UnmarshalBSON implements the bson.Unmarshaler interface.
 func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 	var temp unmarshalCollectionSpecification
@@ -272,8 +338,13 @@ func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 		cs.Type = "collection"
 	}
 	if temp.Info != nil {
-		cs.ReadOnly = temp.Info.ReadOnly
-		cs.UUID = temp.Info.UUID
+		var info unmarshalCollectionInfo
+		if err := bson.Unmarshal(temp.Info, &info); err != nil {
+			return err
+		}
+		cs.ReadOnly = info.ReadOnly
+		cs.UUID = info.UUID
+		cs.Info = temp.Info
 	}
 	cs.Options = temp.Options
 	cs.IDIndex = temp.IDIndex
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 )
 
@@ -79,4 +80,27 @@ func TestResults(t *testing.T) {
 			assert.Equal(t, int32(3), upsertedID, "expected upsertedID 3, got %v", upsertedID)
 		})
 	})
+	t.Run("collection specification", func(t *testing.T) {
+		uuid := primitive.Binary{Subtype: 0x04, Data: []byte("0123456789abcdef")}
+		info := bson.D{{"readOnly", true}, {"uuid", uuid}}
+		doc := bson.D{
+			{"name", "foo"},
+			{"type", "view"},
+			{"options", bson.D{{"viewOn", "bar"}}},
+			{"info", info},
+		}
+		b, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		infoBytes, err := bson.Marshal(info)
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		var spec CollectionSpecification
+		err = bson.Unmarshal(b, &spec)
+		assert.Nil(t, err, "Unmarshal error: %v", err)
+		assert.Equal(t, "foo", spec.Name, "expected Name 'foo', got %q", spec.Name)
+		assert.Equal(t, "view", spec.Type, "expected Type 'view', got %q", spec.Type)
+		assert.True(t, spec.ReadOnly, "expected ReadOnly true, got false")
+		assert.Equal(t, &uuid, spec.UUID, "expected UUID %v, got %v", uuid, spec.UUID)
+		assert.Equal(t, bson.Raw(infoBytes), spec.Info, "expected Info %v, got %v", bson.Raw(infoBytes), spec.Info)
+	})
 }
@@ -34,8 +34,8 @@ var withTransactionTimeout = 120 * time.Second
 // Implementations of SessionContext are not safe for concurrent use by multiple goroutines.
 //
 // There are two ways to create a SessionContext and use it in a session/transaction. The first is to use one of the
-// callback-based functions such as WithSession and UseSession. These functions create a SessionContext and pass it to
-// the provided callback. The other is to use NewSessionContext to explicitly create a SessionContext.
+// callback-based functions such as WithSessionContext and UseSession. These functions create a SessionContext and pass
+// it to the provided callback. The other is to use NewSessionContext to explicitly create a SessionContext.
 type SessionContext interface {
 	context.Context
 	Session
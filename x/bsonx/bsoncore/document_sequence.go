@@ -132,16 +132,21 @@ func (ds *DocumentSequence) Documents() ([]Document, error) {
 		if len(ds.Data) == 0 {
 			return nil, nil
 		}
-		vals, err := Document(ds.Data).Values()
-		if err != nil {
+		// Validate all values before checking their types so corrupted sequences are reported consistently.
+		if err := Document(ds.Data).Validate(); err != nil {
 			return nil, ErrCorruptedDocument
 		}
-		docs := make([]Document, 0, len(vals))
-		for _, v := range vals {
-			if v.Type != bsontype.EmbeddedDocument {
+		// Read the elements directly rather than using Document.Values to avoid allocating an intermediate slice.
+		_, rem, _ := ReadLength(ds.Data)
+		docs := make([]Document, 0, ds.DocumentCount())
+		var elem Element
+		for len(rem) > 1 {
+			elem, rem, _ = ReadElement(rem)
+			val := elem.Value()
+			if val.Type != bsontype.EmbeddedDocument {
 				return nil, ErrNonDocument
 			}
-			docs = append(docs, v.Data)
+			docs = append(docs, val.Data)
 		}
 		return docs, nil
 	default:
@@ -83,6 +83,13 @@ func TestBatches(t *testing.T) {
 				10, 5, 100, nil,
 				&Batches{Documents: documents[1:], Current: documents[:1]},
 			},
+			{
+				// the documents fit in targetBatchSize, but the batch is limited to the server's maxWriteBatchSize
+				"more documents than maxCount",
+				&Batches{Documents: documents},
+				2, 600, 1000, nil,
+				&Batches{Documents: documents[2:], Current: documents[:2]},
+			},
 		}
 
 		for _, tc := range testCases {
@@ -28,6 +28,12 @@ type Connector interface {
 	Connect() error
 }
 
+// ContextConnector represents a type that can connect to a server and cancel its initial connection attempts when a
+// context expires.
+type ContextConnector interface {
+	ConnectContext(context.Context) error
+}
+
 // Disconnector represents a type that can disconnect from a server.
 type Disconnector interface {
 	Disconnect(context.Context) error
@@ -1291,9 +1291,11 @@ func (op Operation) addServerAPI(dst []byte) []byte {
 	return dst
 }
 
-func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer) ([]byte, error) {
+// readConcern returns the read concern that addReadConcern appends to the command sent to the server described by
+// desc, or nil if no read concern is appended.
+func (op Operation) readConcern(desc description.SelectedServer) (*readconcern.ReadConcern, error) {
 	if op.MinimumReadConcernWireVersion > 0 && (desc.WireVersion == nil || !desc.WireVersion.Includes(op.MinimumReadConcernWireVersion)) {
-		return dst, nil
+		return nil, nil
 	}
 	rc := op.ReadConcern
 	client := op.Client
@@ -1309,13 +1311,28 @@ func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer)
 
 	if client != nil && client.Snapshot {
 		if desc.WireVersion.Max < readSnapshotMinWireVersion {
-			return dst, errors.New("snapshot reads require MongoDB 5.0 or later")
+			return nil, errors.New("snapshot reads require MongoDB 5.0 or later")
 		}
 		rc = readconcern.Snapshot()
 	}
+	return rc, nil
+}
 
-	if rc == nil {
-		return dst, nil
+// AppendsAfterClusterTime returns true if the read concern of the command sent to the server described by desc
+// includes an afterClusterTime field.
+func (op Operation) AppendsAfterClusterTime(desc description.SelectedServer) bool {
+	rc, err := op.readConcern(desc)
+	if err != nil || rc == nil {
+		return false
+	}
+	client := op.Client
+	return sessionsSupported(desc.WireVersion) && client != nil && client.Consistent && client.OperationTime != nil
+}
+
+func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	rc, err := op.readConcern(desc)
+	if err != nil || rc == nil {
+		return dst, err
 	}
 
 	_, data, err := rc.MarshalBSONValue() // always returns a document
@@ -1323,6 +1340,7 @@ func (op Operation) addReadConcern(dst []byte, desc description.SelectedServer)
 		return dst, err
 	}
 
+	client := op.Client
 	if sessionsSupported(desc.WireVersion) && client != nil {
 		if client.Consistent && client.OperationTime != nil {
 			data = data[:len(data)-1] // remove the null byte
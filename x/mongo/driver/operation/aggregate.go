@@ -50,7 +50,8 @@ type Aggregate struct {
 	customOptions            map[string]bsoncore.Value
 	timeout                  *time.Duration
 
-	result driver.CursorResponse
+	result           driver.CursorResponse
+	afterClusterTime bool
 }
 
 // NewAggregate constructs and returns a new Aggregate.
@@ -76,6 +77,12 @@ func (a *Aggregate) ResultCursorResponse() driver.CursorResponse {
 	return a.result
 }
 
+// AfterClusterTime returns true if the read concern of the most recently sent aggregate command included an
+// afterClusterTime field.
+func (a *Aggregate) AfterClusterTime() bool {
+	return a.afterClusterTime
+}
+
 func (a *Aggregate) processResponse(info driver.ResponseInfo) error {
 	var err error
 
@@ -121,6 +128,7 @@ func (a *Aggregate) command(dst []byte, desc description.SelectedServer) ([]byte
 		header = bsoncore.Value{Type: bsontype.Int32, Data: []byte{0x01, 0x00, 0x00, 0x00}}
 	}
 	dst = bsoncore.AppendValueElement(dst, "aggregate", header)
+	a.afterClusterTime = driver.Operation{Client: a.session, ReadConcern: a.readConcern}.AppendsAfterClusterTime(desc)
 
 	cursorIdx, cursorDoc := bsoncore.AppendDocumentStart(nil)
 	if a.allowDiskUse != nil {
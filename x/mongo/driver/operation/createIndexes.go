@@ -24,6 +24,7 @@ import (
 // CreateIndexes performs a createIndexes operation.
 type CreateIndexes struct {
 	commitQuorum bsoncore.Value
+	hidden       bool
 	indexes      bsoncore.Document
 	maxTime      *time.Duration
 	session      *session.Client
@@ -129,6 +130,9 @@ func (ci *CreateIndexes) command(dst []byte, desc description.SelectedServer) ([
 		}
 		dst = bsoncore.AppendValueElement(dst, "commitQuorum", ci.commitQuorum)
 	}
+	if ci.hidden && (desc.WireVersion == nil || !desc.WireVersion.Includes(9)) {
+		return nil, ErrHiddenIndexNotSupported
+	}
 	if ci.indexes != nil {
 		dst = bsoncore.AppendArrayElement(dst, "indexes", ci.indexes)
 	}
@@ -147,6 +151,17 @@ func (ci *CreateIndexes) CommitQuorum(commitQuorum bsoncore.Value) *CreateIndexe
 	return ci
 }
 
+// Hidden specifies whether any of the indexes being created are hidden. Hidden indexes require a minimum server wire
+// version of 9.
+func (ci *CreateIndexes) Hidden(hidden bool) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.hidden = hidden
+	return ci
+}
+
 // Indexes specifies an array containing index specification documents for the indexes being created.
 func (ci *CreateIndexes) Indexes(indexes bsoncore.Document) *CreateIndexes {
 	if ci == nil {
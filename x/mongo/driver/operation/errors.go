@@ -10,4 +10,8 @@ import "errors"
 
 var (
 	errUnacknowledgedHint = errors.New("the 'hint' command parameter cannot be used with unacknowledged writes")
+
+	// ErrHiddenIndexNotSupported is returned by CreateIndexes if a hidden index is created on a server with a wire
+	// version less than 9.
+	ErrHiddenIndexNotSupported = errors.New("the 'hidden' index option requires a minimum server wire version of 9")
 )
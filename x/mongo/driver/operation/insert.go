@@ -42,6 +42,7 @@ type Insert struct {
 	serverAPI                *driver.ServerAPIOptions
 	timeout                  *time.Duration
 	logger                   *logger.Logger
+	progressFn               func(inserted int64)
 }
 
 // InsertResult represents an insert result returned by the server.
@@ -82,6 +83,9 @@ func (i *Insert) Result() InsertResult { return i.result }
 func (i *Insert) processResponse(info driver.ResponseInfo) error {
 	ir, err := buildInsertResult(info.ServerResponse)
 	i.result.N += ir.N
+	if err == nil && i.progressFn != nil {
+		i.progressFn(i.result.N)
+	}
 	return err
 }
 
@@ -174,6 +178,17 @@ func (i *Insert) Ordered(ordered bool) *Insert {
 	return i
 }
 
+// ProgressFn sets a callback that is invoked after the response for each batch of documents is processed. The
+// callback is passed the total number of documents inserted so far.
+func (i *Insert) ProgressFn(fn func(inserted int64)) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.progressFn = fn
+	return i
+}
+
 // Session sets the session for this operation.
 func (i *Insert) Session(session *session.Client) *Insert {
 	if i == nil {
@@ -240,6 +240,41 @@ func TestOperation(t *testing.T) {
 			}
 		}
 	})
+	t.Run("AppendsAfterClusterTime", func(t *testing.T) {
+		sessPool := session.NewPool(nil)
+		id, err := uuid.New()
+		noerr(t, err)
+
+		sess, err := session.NewClientSession(sessPool, id, session.Explicit)
+		noerr(t, err)
+		sess.OperationTime = &primitive.Timestamp{T: 1234, I: 4567}
+		desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Min: 0, Max: 7}}}
+
+		testCases := []struct {
+			name string
+			op   Operation
+			want bool
+		}{
+			{"no read concern", Operation{Client: sess}, false},
+			{"read concern", Operation{Client: sess, ReadConcern: readconcern.Majority()}, true},
+			{"no session", Operation{ReadConcern: readconcern.Majority()}, false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := tc.op.AppendsAfterClusterTime(desc)
+				if got != tc.want {
+					t.Errorf("AppendsAfterClusterTime does not match. got %v; want %v", got, tc.want)
+				}
+				rc, err := tc.op.addReadConcern(nil, desc)
+				noerr(t, err)
+				_, lookupErr := bsoncore.Document(bsoncore.BuildDocument(nil, rc)).LookupErr("readConcern", "afterClusterTime")
+				if inCmd := lookupErr == nil; inCmd != tc.want {
+					t.Errorf("afterClusterTime in read concern does not match. got %v; want %v", inCmd, tc.want)
+				}
+			})
+		}
+	})
 	t.Run("addWriteConcern", func(t *testing.T) {
 		want := bsoncore.AppendDocumentElement(nil, "writeConcern", bsoncore.BuildDocumentFromElements(
 			nil, bsoncore.AppendStringElement(nil, "w", "majority"),
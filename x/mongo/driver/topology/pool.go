@@ -73,6 +73,7 @@ type poolConfig struct {
 	MaxIdleTime      time.Duration
 	MaintainInterval time.Duration
 	PoolMonitor      *event.PoolMonitor
+	WarmupContext    context.Context
 	handshakeErrFn   func(error, uint64, *primitive.ObjectID)
 }
 
@@ -101,6 +102,7 @@ type pool struct {
 
 	maintainInterval time.Duration   // maintainInterval is the maintain() loop interval.
 	maintainReady    chan struct{}   // maintainReady is a signal channel that starts the maintain() loop when ready() is called.
+	warmupCtx        context.Context // warmupCtx cancels the maintain() connection attempts started before it expires.
 	backgroundDone   *sync.WaitGroup // backgroundDone waits for all background goroutines to return.
 
 	stateMu      sync.RWMutex // stateMu guards state, lastClearErr
@@ -171,6 +173,7 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 		state:                 poolPaused,
 		maintainInterval:      maintainInterval,
 		maintainReady:         make(chan struct{}, 1),
+		warmupCtx:             config.WarmupContext,
 		backgroundDone:        &sync.WaitGroup{},
 		createConnectionsCond: sync.NewCond(&sync.Mutex{}),
 		conns:                 make(map[uint64]*connection, config.MaxPoolSize),
@@ -839,7 +842,9 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 
 		// Pass the createConnections context to connect to allow pool close to cancel connection
 		// establishment so shutdown doesn't block indefinitely if connectTimeout=0.
-		err := conn.connect(ctx)
+		connectCtx, cancel := connectContext(ctx, w)
+		err := conn.connect(connectCtx)
+		cancel()
 		if err != nil {
 			w.tryDeliver(nil, err)
 
@@ -849,7 +854,9 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 			// handshake error handler may clear the connection pool, leading to a different error
 			// message being delivered to the same waiting wantConn in idleConnWait when the wait
 			// queues are cleared.
-			if p.handshakeErrFn != nil {
+			// A connection attempt cancelled by the wantConn's context says nothing about the
+			// state of the server, so don't handle it as a handshake error.
+			if p.handshakeErrFn != nil && (w.ctx == nil || w.ctx.Err() == nil) {
 				p.handshakeErrFn(err, conn.generation, conn.desc.ServiceID)
 			}
 
@@ -946,8 +953,16 @@ func (p *pool) maintain(ctx context.Context, wg *sync.WaitGroup) {
 			n = 10
 		}
 
+		// Connection attempts started before the warmup Context expires are cancelled when it
+		// expires. Later attempts are only bound by the lifetime of the pool.
+		var warmupCtx context.Context
+		if p.warmupCtx != nil && p.warmupCtx.Done() != nil && p.warmupCtx.Err() == nil {
+			warmupCtx = p.warmupCtx
+		}
+
 		for i := 0; i < n; i++ {
 			w := newWantConn()
+			w.ctx = warmupCtx
 			p.queueForNewConn(w)
 			wantConns = append(wantConns, w)
 
@@ -986,6 +1001,28 @@ func (p *pool) removePerishedConns() {
 	p.idleConns = compact(p.idleConns)
 }
 
+// connectContext returns a Context that is cancelled when either ctx or the Context of w is done
+// and a function that releases its resources.
+func connectContext(ctx context.Context, w *wantConn) (context.Context, context.CancelFunc) {
+	if w.ctx == nil {
+		return ctx, func() {}
+	}
+
+	connectCtx, cancel := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-w.ctx.Done():
+			cancel()
+		case <-stop:
+		}
+	}()
+	return connectCtx, func() {
+		close(stop)
+		cancel()
+	}
+}
+
 // compact removes any nil pointers from the slice and keeps the non-nil pointers, retaining the
 // order of the non-nil pointers.
 func compact(arr []*connection) []*connection {
@@ -1008,6 +1045,10 @@ func compact(arr []*connection) []*connection {
 type wantConn struct {
 	ready chan struct{}
 
+	// ctx, if not nil, cancels establishing a new connection for the wantConn in addition to
+	// the pool's background Context.
+	ctx context.Context
+
 	mu   sync.Mutex // Guards conn, err
 	conn *connection
 	err  error
@@ -11,9 +11,11 @@ import (
 	"errors"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
@@ -1007,6 +1009,44 @@ func TestPool(t *testing.T) {
 
 			p.close(context.Background())
 		})
+		t.Run("cancels MinPoolSize connection attempts when the warmup Context expires", func(t *testing.T) {
+			t.Parallel()
+
+			dialing := make(chan struct{}, 1)
+			dialErr := make(chan error, 1)
+			var handshakeErrs int32
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			p := newPool(poolConfig{
+				Address:       "localhost:27017",
+				MinPoolSize:   1,
+				WarmupContext: ctx,
+				handshakeErrFn: func(error, uint64, *primitive.ObjectID) {
+					atomic.AddInt32(&handshakeErrs, 1)
+				},
+			}, WithDialer(func(Dialer) Dialer {
+				return DialerFunc(func(ctx context.Context, _, _ string) (net.Conn, error) {
+					dialing <- struct{}{}
+					<-ctx.Done()
+					dialErr <- ctx.Err()
+					return nil, ctx.Err()
+				})
+			}))
+			err := p.ready()
+			noerr(t, err)
+
+			<-dialing
+			cancel()
+			assert.Equal(t, context.Canceled, <-dialErr, "expected dial to be cancelled")
+			assert.Eventually(t,
+				func() bool { return p.totalConnectionCount() == 0 },
+				3*time.Second,
+				10*time.Millisecond,
+				"expected the cancelled connection to be removed from the pool")
+			assert.Equal(t, int32(0), atomic.LoadInt32(&handshakeErrs), "expected no handshake errors")
+
+			p.close(context.Background())
+		})
 		t.Run("when MinPoolSize > MaxPoolSize should not exceed MaxPoolSize connections", func(t *testing.T) {
 			t.Parallel()
 
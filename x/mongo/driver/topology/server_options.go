@@ -7,6 +7,7 @@
 package topology
 
 import (
+	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -38,6 +39,7 @@ type serverConfig struct {
 	poolMonitor          *event.PoolMonitor
 	poolMaxIdleTime      time.Duration
 	poolMaintainInterval time.Duration
+	poolWarmupCtx        context.Context
 }
 
 func newServerConfig(opts ...ServerOption) *serverConfig {
@@ -72,6 +74,13 @@ func withMonitoringDisabled(fn func(bool) bool) ServerOption {
 	}
 }
 
+// withWarmupContext configures the context that bounds the connection attempts made to reach the minimum pool size.
+func withWarmupContext(fn func(context.Context) context.Context) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.poolWarmupCtx = fn(cfg.poolWarmupCtx)
+	}
+}
+
 // WithConnectionOptions configures the server's connections.
 func WithConnectionOptions(fn func(...ConnectionOption) []ConnectionOption) ServerOption {
 	return func(cfg *serverConfig) {
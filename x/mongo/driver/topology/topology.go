@@ -104,6 +104,10 @@ type Topology struct {
 	serversClosed bool
 	servers       map[address.Address]*Server
 
+	// The context passed to ConnectContext. Connection attempts that the servers' pools make to reach their minimum
+	// size are cancelled when it expires.
+	warmupCtx context.Context
+
 	id primitive.ObjectID
 }
 
@@ -160,9 +164,17 @@ func New(cfg *Config) (*Topology, error) {
 // Connect initializes a Topology and starts the monitoring process. This function
 // must be called to properly monitor the topology.
 func (t *Topology) Connect() error {
+	return t.ConnectContext(context.Background())
+}
+
+// ConnectContext is like Connect, but the connection attempts that the servers' connection pools make to reach their
+// minimum size are cancelled if ctx expires before they complete. Once ctx has expired, the pools continue to
+// maintain their minimum size for the lifetime of the Topology.
+func (t *Topology) ConnectContext(ctx context.Context) error {
 	if !atomic.CompareAndSwapInt64(&t.state, topologyDisconnected, topologyConnecting) {
 		return ErrTopologyConnected
 	}
+	t.warmupCtx = ctx
 
 	t.desc.Store(description.Topology{})
 	var err error
@@ -771,7 +783,10 @@ func (t *Topology) addServer(addr address.Address) error {
 		return nil
 	}
 
-	svr, err := ConnectServer(addr, t.updateCallback, t.id, t.cfg.ServerOpts...)
+	opts := make([]ServerOption, 0, len(t.cfg.ServerOpts)+1)
+	opts = append(opts, t.cfg.ServerOpts...)
+	opts = append(opts, withWarmupContext(func(context.Context) context.Context { return t.warmupCtx }))
+	svr, err := ConnectServer(addr, t.updateCallback, t.id, opts...)
 	if err != nil {
 		return err
 	}